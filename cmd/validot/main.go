@@ -0,0 +1,374 @@
+// cmd/validot/main.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mwiater/go-validot"
+	"github.com/sirupsen/logrus"
+)
+
+const usage = `Usage: validot <command> [flags] <file>...
+
+Commands:
+  validate  Validate .env files using the built-in plugins.
+  fix       Rewrite .env files to correct mechanical formatting problems.
+  lint      Report syntax that is not portable between docker, shells and godotenv.
+  watch     Re-validate .env files whenever they change.
+
+Run 'validot <command> -h' for the flags of a command.
+`
+
+// commonFlags holds the flags shared by all commands.
+type commonFlags struct {
+	required       string          // A comma-separated list of required keys.
+	optional       string          // A comma-separated list of optional keys.
+	schemaFile     string          // The path to a schema file declaring required keys, optional keys and defaults.
+	schema         *validot.Schema // The schema loaded from schemaFile by loadSchema, if any.
+	unknownKeys    unknownKeysFlag // How keys that are neither declared nor handled by a plugin are reported.
+	ignorePrefixes string          // A comma-separated list of key prefixes exempt from unknown-key checks.
+	keyStyle       keyStyleFlag    // The case style keys must follow.
+	keyPrefix      string          // A prefix every key must start with.
+	keyMaxLength   int             // The maximum length of a key.
+	requireQuotes  bool            // Whether values must be quoted.
+	verbose        bool            // Whether verbose logging is enabled.
+}
+
+// unknownKeysFlag is a flag.Value that parses a validot.UnknownKeyMode.
+type unknownKeysFlag struct {
+	mode validot.UnknownKeyMode
+}
+
+// String returns the name of the mode.
+func (f *unknownKeysFlag) String() string {
+	return f.mode.String()
+}
+
+// Set parses "allow", "warn" or "error".
+func (f *unknownKeysFlag) Set(value string) error {
+	for _, mode := range []validot.UnknownKeyMode{validot.UnknownKeysAllow, validot.UnknownKeysWarn, validot.UnknownKeysError} {
+		if value == mode.String() {
+			f.mode = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("must be allow, warn or error")
+}
+
+// keyStyleFlag is a flag.Value that parses a validot.KeyStyle.
+type keyStyleFlag struct {
+	style validot.KeyStyle
+}
+
+// String returns the name of the style.
+func (f *keyStyleFlag) String() string {
+	return f.style.String()
+}
+
+// Set parses "any", "upper" or "lower".
+func (f *keyStyleFlag) Set(value string) error {
+	switch value {
+	case "any":
+		f.style = validot.KeyStyleAny
+	case "upper":
+		f.style = validot.KeyStyleUpperSnake
+	case "lower":
+		f.style = validot.KeyStyleLowerSnake
+	default:
+		return fmt.Errorf("must be any, upper or lower")
+	}
+	return nil
+}
+
+// register adds the common flags to the given flag set.
+func (c *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.required, "required", "", "comma-separated list of required keys")
+	fs.StringVar(&c.optional, "optional", "", "comma-separated list of optional keys")
+	fs.StringVar(&c.schemaFile, "schema", "", "JSON schema file declaring required keys, optional keys and defaults")
+	fs.Var(&c.unknownKeys, "unknown", "how to report keys that are neither declared nor handled by a plugin: allow, warn or error")
+	fs.StringVar(&c.ignorePrefixes, "ignore-prefixes", "", "comma-separated list of key prefixes exempt from -unknown")
+	fs.Var(&c.keyStyle, "key-style", "case style keys must follow: any, upper (UPPER_SNAKE_CASE) or lower (lower_snake_case)")
+	fs.StringVar(&c.keyPrefix, "key-prefix", "", "prefix every key must start with, e.g. PAYMENTS_")
+	fs.IntVar(&c.keyMaxLength, "key-max-length", 0, "maximum length of a key; 0 means no limit")
+	fs.BoolVar(&c.requireQuotes, "quotes", false, "require values to be quoted")
+	fs.BoolVar(&c.verbose, "verbose", false, "enable verbose logging")
+}
+
+// loadSchema loads the -schema file, if any, so that config and requiredKeys include it.
+func (c *commonFlags) loadSchema() error {
+	if c.schemaFile == "" {
+		return nil
+	}
+	schema, err := validot.LoadSchema(c.schemaFile)
+	if err != nil {
+		return err
+	}
+	c.schema = schema
+	return nil
+}
+
+// newValidator creates a Validator from the common flags.
+func (c *commonFlags) newValidator(logOutput io.Writer) (*validot.Validator, error) {
+	if err := c.loadSchema(); err != nil {
+		return nil, err
+	}
+	return validot.NewValidator(c.config(logOutput), c.requiredKeys()), nil
+}
+
+// config builds a validot.Config from the common flags and the loaded schema.
+func (c *commonFlags) config(logOutput io.Writer) validot.Config {
+	logger := logrus.New()
+	logger.SetOutput(logOutput)
+
+	config := validot.Config{
+		RequireQuotes:      c.requireQuotes,
+		Verbose:            c.verbose,
+		Logger:             logger,
+		OptionalKeys:       splitList(c.optional),
+		UnknownKeys:        c.unknownKeys.mode,
+		IgnoredKeyPrefixes: splitList(c.ignorePrefixes),
+		KeyNaming: validot.KeyNamingRules{
+			Style:     c.keyStyle.style,
+			Prefix:    c.keyPrefix,
+			MaxLength: c.keyMaxLength,
+		},
+	}
+	if c.schema != nil {
+		config = c.schema.Apply(config)
+	}
+	return config
+}
+
+// requiredKeys splits the -required flag into a list of keys, followed by the required keys of the loaded schema.
+func (c *commonFlags) requiredKeys() []string {
+	keys := splitList(c.required)
+	if c.schema != nil {
+		keys = append(keys, c.schema.RequiredKeys()...)
+	}
+	return keys
+}
+
+// splitList splits a comma-separated flag value, dropping empty elements.
+func splitList(value string) []string {
+	var list []string
+	for _, element := range strings.Split(value, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			list = append(list, element)
+		}
+	}
+	return list
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "validate":
+		err = runValidate(os.Args[2:])
+	case "fix":
+		err = runFix(os.Args[2:])
+	case "lint":
+		err = runLint(os.Args[2:])
+	case "watch":
+		err = runWatch(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "validot: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validot: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runValidate implements the `validate` command.
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	validator, err := common.newValidator(os.Stderr)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, file := range files {
+		if err := validator.ValidateDotEnv(file); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed validation", failed, len(files))
+	}
+	return nil
+}
+
+// runFix implements the `fix` command. Like gofmt, it prints the fixed file to
+// standard output unless -w or -d is given.
+func runFix(args []string) error {
+	fs := flag.NewFlagSet("fix", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+	write := fs.Bool("w", false, "write the fixed file in place instead of to standard output")
+	diff := fs.Bool("d", false, "print a unified diff of the changes")
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	validator, err := common.newValidator(os.Stderr)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		result, err := validator.Fix(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		if *diff {
+			fmt.Fprint(os.Stdout, result.Diff())
+		}
+		if *write {
+			if err := result.WriteFile(); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+		}
+		if !*write && !*diff {
+			os.Stdout.Write(result.Fixed)
+		}
+	}
+	return nil
+}
+
+// runLint implements the `lint` command. It prints every finding and fails if any of
+// them is an error.
+func runLint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	warn := fs.String("warn", "", "comma-separated list of lint rules reported as warnings")
+	disable := fs.String("disable", "", "comma-separated list of lint rules to disable")
+	fs.Parse(args)
+
+	levels := make(map[validot.LintRule]validot.LintLevel)
+	for _, setting := range []struct {
+		names string
+		level validot.LintLevel
+	}{{*warn, validot.LintLevelWarning}, {*disable, validot.LintLevelOff}} {
+		for _, name := range splitList(setting.names) {
+			rule, err := parseLintRule(name)
+			if err != nil {
+				return err
+			}
+			levels[rule] = setting.level
+		}
+	}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	validator := validot.NewValidator(validot.Config{Lint: levels}, nil)
+	errorCount := 0
+	for _, file := range files {
+		findings, err := validator.Lint(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		for _, finding := range findings {
+			fmt.Fprintln(os.Stdout, finding)
+			if finding.Severity == validot.SeverityError {
+				errorCount++
+			}
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("%d lint errors", errorCount)
+	}
+	return nil
+}
+
+// parseLintRule returns the lint rule with the given name, e.g. "crlf".
+func parseLintRule(name string) (validot.LintRule, error) {
+	var names []string
+	for _, rule := range validot.LintRules {
+		if name == rule.String() {
+			return rule, nil
+		}
+		names = append(names, rule.String())
+	}
+	return 0, fmt.Errorf("unknown lint rule %q; expected one of %s", name, strings.Join(names, ", "))
+}
+
+// runWatch implements the `watch` command. It prints findings as they are introduced
+// (`+`) or fixed (`-`) until interrupted.
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	var common commonFlags
+	common.register(fs)
+	interval := fs.Duration("interval", validot.DefaultWatchInterval, "how often to poll files for changes")
+	debounce := fs.Duration("debounce", validot.DefaultWatchDebounce, "how long a file must be unchanged before it is re-validated; 0 disables debouncing")
+	fs.Parse(args)
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	if err := common.loadSchema(); err != nil {
+		return err
+	}
+	config := common.config(os.Stderr)
+	config.WatchInterval = *interval
+	config.WatchDebounce = *debounce
+	if *debounce == 0 {
+		// A zero Config.WatchDebounce means the default, so disable debouncing explicitly.
+		config.WatchDebounce = -1
+	}
+	validator := validot.NewValidator(config, common.requiredKeys())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := validator.Watch(ctx, files, func(event validot.WatchEvent) {
+		timestamp := time.Now().Format(time.TimeOnly)
+		if event.Err != nil {
+			fmt.Fprintf(os.Stdout, "[%s] %s: %v\n", timestamp, event.File, event.Err)
+			return
+		}
+		for _, finding := range event.Fixed {
+			fmt.Fprintf(os.Stdout, "[%s] - %s\n", timestamp, finding)
+		}
+		for _, finding := range event.Introduced {
+			fmt.Fprintf(os.Stdout, "[%s] + %s\n", timestamp, finding)
+		}
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
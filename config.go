@@ -1,16 +1,114 @@
 package validot
 
 import (
+	"time"
+
 	"github.com/mwiater/go-validot/plugins"
 	"github.com/sirupsen/logrus"
 )
 
+// InterpolationMode controls which form of a value is handed to validation plugins
+// when the value contains `$KEY` or `${KEY}` references.
+type InterpolationMode int
+
+const (
+	// InterpolateExpanded validates values after references have been expanded. This is the default.
+	InterpolateExpanded InterpolationMode = iota
+	// InterpolateRaw validates values exactly as written, without expanding references.
+	InterpolateRaw
+)
+
+// String returns a human-readable name for the interpolation mode.
+//
+// Returns:
+//   - string: The name of the interpolation mode.
+func (m InterpolationMode) String() string {
+	switch m {
+	case InterpolateRaw:
+		return "raw"
+	default:
+		return "expanded"
+	}
+}
+
+// KeyOrder controls the order in which keys are validated, logged and reported.
+type KeyOrder int
+
+const (
+	// KeyOrderFile validates keys in the order they first appear in the `.env` file. This is the default.
+	KeyOrderFile KeyOrder = iota
+	// KeyOrderSorted validates keys in lexicographic order.
+	KeyOrderSorted
+)
+
+// String returns a human-readable name for the key order.
+//
+// Returns:
+//   - string: The name of the key order.
+func (o KeyOrder) String() string {
+	switch o {
+	case KeyOrderSorted:
+		return "sorted"
+	default:
+		return "file"
+	}
+}
+
+// UnknownKeyMode controls how keys that are neither declared nor handled by a plugin are reported.
+type UnknownKeyMode int
+
+const (
+	// UnknownKeysAllow accepts unknown keys. This is the default.
+	UnknownKeysAllow UnknownKeyMode = iota
+	// UnknownKeysWarn reports unknown keys as warnings.
+	UnknownKeysWarn
+	// UnknownKeysError fails validation on unknown keys.
+	UnknownKeysError
+)
+
+// String returns a human-readable name for the unknown key mode.
+//
+// Returns:
+//   - string: The name of the unknown key mode.
+func (m UnknownKeyMode) String() string {
+	switch m {
+	case UnknownKeysWarn:
+		return "warn"
+	case UnknownKeysError:
+		return "error"
+	default:
+		return "allow"
+	}
+}
+
+// DeprecatedKey describes a key that has been renamed or retired, such as `REDIS_HOST`
+// after it was renamed to `CACHE_HOST`.
+type DeprecatedKey struct {
+	Replacement string // The key that replaces the deprecated key, if any.
+	MapValue    bool   // If true and the replacement is not set, the deprecated key's value is used for the replacement before validation.
+}
+
 // Config represents the configuration settings for a Validator.
 // This structure defines the behavior of the validation process,
 // including logging, verbosity, and custom plugins.
 type Config struct {
-	RequireQuotes bool                       // If true, enforces that all values in the `.env` file must be quoted.
-	Verbose       bool                       // If true, enables detailed logging for the validation process.
-	Logger        *logrus.Logger             // A custom logger instance for logging messages; if nil, a default logger will be used.
-	Plugins       []plugins.ValidationPlugin // A list of user-defined validation plugins to extend the validation capabilities.
+	RequireQuotes      bool                       // If true, enforces that all values in the `.env` file must be quoted.
+	Verbose            bool                       // If true, enables detailed logging for the validation process.
+	Logger             *logrus.Logger             // A custom logger instance for logging messages; if nil, a default logger will be used.
+	Plugins            []plugins.ValidationPlugin // A list of user-defined validation plugins to extend the validation capabilities.
+	Interpolation      InterpolationMode          // Whether plugins validate expanded or raw values; references are always checked.
+	FileOnlyReferences bool                       // If true, references must be defined in the file; by default, they fall back to the process environment (e.g. `${HOME}`).
+	Defaults           map[string]string          // Values for keys the `.env` file does not set; defaults are validated by the plugins like values from the file.
+	DeprecatedKeys     map[string]DeprecatedKey   // Deprecated keys, keyed by their old name; they are reported as warnings and can be mapped onto their replacements.
+	OptionalKeys       []string                   // Keys that may be set but are not required; like required keys, they are known keys for UnknownKeys.
+	UnknownKeys        UnknownKeyMode             // Whether keys that are neither declared nor handled by a plugin are allowed, warned about or rejected; defaults to UnknownKeysAllow.
+	IgnoredKeyPrefixes []string                   // Key prefixes exempt from UnknownKeys, e.g. "OTEL_" for variables read by other tools.
+	KeyNaming          KeyNamingRules             // Rules for the names of the keys in the file, such as UPPER_SNAKE_CASE or a service prefix; the zero value enforces none.
+	Lint               map[LintRule]LintLevel     // Levels for the rules checked by Lint, such as LintLevelOff for LintExportPrefix; rules not listed are errors.
+	KeyOrder           KeyOrder                   // The order in which keys are validated and reported; defaults to KeyOrderFile.
+	Parallelism        int                        // The number of keys validated concurrently; 0 or 1 validates sequentially.
+	PluginTimeout      time.Duration              // The maximum time a plugin may spend validating a single key; 0 means no limit.
+	PluginTimeouts     map[string]time.Duration   // Per-plugin timeouts keyed by plugin name, overriding PluginTimeout.
+	WatchInterval      time.Duration              // How often Watch polls files for changes; defaults to DefaultWatchInterval.
+	WatchDebounce      time.Duration              // How long a file must be unchanged before Watch re-validates it; defaults to DefaultWatchDebounce, negative disables.
 }
@@ -0,0 +1,91 @@
+package validot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// CanceledError is returned when validation stops because its context was canceled
+// or its deadline passed. It lists the keys that were not fully checked.
+type CanceledError struct {
+	Unchecked []string // The keys that were not checked by every plugin.
+	Err       error    // The context's error.
+}
+
+// Error returns a description of the cancellation and the keys that were not checked.
+//
+// Returns:
+//   - string: The error message.
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("validation stopped: %v (%d keys not checked: %s)", e.Err, len(e.Unchecked), strings.Join(e.Unchecked, ", "))
+}
+
+// Unwrap returns the context's error, so that errors.Is(err, context.Canceled) works.
+//
+// Returns:
+//   - error: The context's error.
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// pluginContext returns a copy of ctx that carries the path and values of the `.env`
+// file being validated, for plugins that need them.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - filePath: The path to the `.env` file.
+//   - values: The values of the `.env` file, keyed by key.
+//
+// Returns:
+//   - context.Context: The derived context.
+func pluginContext(ctx context.Context, filePath string, values map[string]string) context.Context {
+	return plugins.ContextWithEnvValues(plugins.ContextWithEnvFile(ctx, filePath), values)
+}
+
+// pluginTimeout returns the timeout that applies to a plugin, preferring a
+// per-plugin timeout from Config.PluginTimeouts over Config.PluginTimeout.
+//
+// Parameters:
+//   - plugin: The plugin about to be run.
+//
+// Returns:
+//   - time.Duration: The timeout, or 0 if the plugin is not time-limited.
+func (v *Validator) pluginTimeout(plugin plugins.ValidationPlugin) time.Duration {
+	if timeout, ok := v.config.PluginTimeouts[plugin.Name()]; ok {
+		return timeout
+	}
+	return v.config.PluginTimeout
+}
+
+// runPlugin validates a key with a single plugin, applying the configured timeout.
+// If the parent context is done, its error is returned unchanged so that callers can
+// tell cancellation apart from a validation failure.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - plugin: The plugin to run.
+//   - key: The key being validated.
+//   - value: The value being validated.
+//
+// Returns:
+//   - bool: Indicates whether the plugin handled the key.
+//   - error: A validation error, a timeout error, or the context's error.
+func (v *Validator) runPlugin(ctx context.Context, plugin plugins.ValidationPlugin, key, value string) (bool, error) {
+	pluginCtx := ctx
+	if timeout := v.pluginTimeout(plugin); timeout > 0 {
+		var cancel context.CancelFunc
+		pluginCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	handled, err := plugins.WithContext(plugin).ValidateContext(pluginCtx, key, value)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) && pluginCtx.Err() != nil {
+		return true, fmt.Errorf("%s timed out validating key %q after %v", plugin.Name(), key, v.pluginTimeout(plugin))
+	}
+	return handled, err
+}
@@ -0,0 +1,116 @@
+// context_test.go
+package validot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// BlockingValidationPlugin is a custom plugin for testing purposes.
+// It blocks forever when validating the "SLOW_KEY" key and does not support contexts.
+type BlockingValidationPlugin struct{}
+
+// Validate blocks forever for "SLOW_KEY".
+func (p *BlockingValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != "SLOW_KEY" {
+		return false, nil
+	}
+	select {}
+}
+
+// Name returns the name of the plugin.
+func (p *BlockingValidationPlugin) Name() string {
+	return "BlockingValidationPlugin"
+}
+
+// SlowContextValidationPlugin is a custom context-aware plugin for testing purposes.
+// It waits for the given delay before accepting any value, unless the context is done first.
+type SlowContextValidationPlugin struct {
+	Delay time.Duration
+}
+
+// Validate validates without a deadline.
+func (p *SlowContextValidationPlugin) Validate(key, value string) (bool, error) {
+	return p.ValidateContext(context.Background(), key, value)
+}
+
+// ValidateContext waits for the delay or until the context is done.
+func (p *SlowContextValidationPlugin) ValidateContext(ctx context.Context, key, value string) (bool, error) {
+	select {
+	case <-time.After(p.Delay):
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *SlowContextValidationPlugin) Name() string {
+	return "SlowContextValidationPlugin"
+}
+
+func TestValidateDotEnvContext_CancellationReportsUncheckedKeys(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "SLOW_KEY=1\n")
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		Logger:  logger,
+		Plugins: []plugins.ValidationPlugin{&BlockingValidationPlugin{}},
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := validator.ValidateDotEnvContext(ctx, envFilePath)
+	assert.Less(t, time.Since(start), time.Second, "Expected validation to stop promptly")
+
+	var canceledErr *CanceledError
+	assert.True(t, errors.As(err, &canceledErr), "Expected a *CanceledError")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, []string{"SLOW_KEY"}, canceledErr.Unchecked)
+}
+
+func TestValidateDotEnvContext_PluginTimeout(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "SLOW_KEY=1\n")
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// The per-plugin timeout overrides the general timeout.
+	validator := NewValidator(Config{
+		Logger:        logger,
+		Plugins:       []plugins.ValidationPlugin{&SlowContextValidationPlugin{Delay: time.Second}},
+		PluginTimeout: time.Minute,
+		PluginTimeouts: map[string]time.Duration{
+			"SlowContextValidationPlugin": 20 * time.Millisecond,
+		},
+	}, nil)
+
+	err := validator.ValidateDotEnvContext(context.Background(), envFilePath)
+	assert.Error(t, err, "Expected a timeout error")
+	assert.Contains(t, err.Error(), `SlowContextValidationPlugin timed out validating key "SLOW_KEY" after 20ms`)
+
+	var canceledErr *CanceledError
+	assert.False(t, errors.As(err, &canceledErr), "A plugin timeout is a validation error, not a cancellation")
+
+	// A fast enough plugin passes.
+	validator = NewValidator(Config{
+		Logger:        logger,
+		Plugins:       []plugins.ValidationPlugin{&SlowContextValidationPlugin{Delay: time.Millisecond}},
+		PluginTimeout: time.Second,
+	}, nil)
+	assert.NoError(t, validator.ValidateDotEnvContext(context.Background(), envFilePath))
+}
@@ -0,0 +1,40 @@
+package validot
+
+import "sort"
+
+// withDefaults returns the entries followed by an entry for each key in Config.Defaults that
+// the entries do not set, in sorted key order. Default values are literal, like single-quoted
+// values, and have no line number; other values may reference them.
+//
+// Parameters:
+//   - entries: The entries parsed from the `.env` file.
+//
+// Returns:
+//   - []envEntry: The entries, including defaults.
+//   - map[string]bool: The keys whose values come from Config.Defaults.
+func (v *Validator) withDefaults(entries []envEntry) ([]envEntry, map[string]bool) {
+	defaulted := make(map[string]bool)
+	if len(v.config.Defaults) == 0 {
+		return entries, defaulted
+	}
+
+	set := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		set[entry.Key] = true
+	}
+
+	keys := make([]string, 0, len(v.config.Defaults))
+	for key := range v.config.Defaults {
+		if !set[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	withDefaults := append([]envEntry{}, entries...)
+	for _, key := range keys {
+		withDefaults = append(withDefaults, envEntry{Key: key, Value: v.config.Defaults[key], Quote: prefixSingleQuote})
+		defaulted[key] = true
+	}
+	return withDefaults, defaulted
+}
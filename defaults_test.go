@@ -0,0 +1,152 @@
+// defaults_test.go
+package validot
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDotEnv_Defaults(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "API_URL=https://api.myapp.com/v1/\nDB_ADDR=${DB_HOST}:${DB_PORT}\nDB_HOST=db.internal\n")
+
+	var logBuffer bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logBuffer)
+
+	validator := NewValidator(Config{
+		Verbose: true,
+		Logger:  logger,
+		Defaults: map[string]string{
+			"DB_HOST":      "localhost", // Set in the file, so not used.
+			"DB_PORT":      "5432",
+			"ENABLE_DEBUG": "false",
+			"ENVIRONMENT":  "DEVELOPMENT",
+		},
+	}, []string{"API_URL"})
+
+	values, err := validator.Load(envFilePath)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "db.internal", values.MustString("DB_HOST"))
+	assert.False(t, values.Defaulted("DB_HOST"))
+	assert.Equal(t, 5432, values.MustInt("DB_PORT"))
+	assert.True(t, values.Defaulted("DB_PORT"))
+	assert.False(t, values.MustBool("ENABLE_DEBUG"))
+	assert.Equal(t, "DEVELOPMENT", values.MustString("ENVIRONMENT"))
+	assert.Equal(t, "db.internal:5432", values.MustString("DB_ADDR"), "Values may reference defaulted keys")
+
+	logs := logBuffer.String()
+	assert.Contains(t, logs, "DB_PORT is not set in the file and is defaulted.")
+	assert.Contains(t, logs, "[Validated by: EnumValidationPlugin]")
+	assert.NotContains(t, logs, "DB_HOST is not set in the file")
+}
+
+func TestValidateDotEnv_InvalidDefault(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "API_URL=https://api.myapp.com/v1/\n")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator := NewValidator(Config{
+		Logger:   logger,
+		Defaults: map[string]string{"ENVIRONMENT": "LOCAL"},
+	}, nil)
+
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid default value: value for key "ENVIRONMENT" must be one of`)
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.True(t, findings[0].Defaulted)
+		assert.Equal(t, 0, findings[0].Line)
+		assert.Equal(t, "EnumValidationPlugin", findings[0].Plugin)
+		assert.Contains(t, findings[0].String(), "(defaulted)")
+	}
+}
+
+func TestValidateDotEnv_DefaultDoesNotSatisfyRequiredKey(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "API_URL=https://api.myapp.com/v1/\n")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator := NewValidator(Config{
+		Logger:   logger,
+		Defaults: map[string]string{"DB_HOST": "localhost"},
+		Plugins:  []plugins.ValidationPlugin{&plugins.HostValidationPlugin{Key: "DB_HOST"}},
+	}, []string{"DB_HOST"})
+
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required keys: [DB_HOST]")
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "missing required key: DB_HOST", findings[0].Message)
+	}
+}
+
+func writeSchemaFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "env.schema.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadSchema_Defaults(t *testing.T) {
+	schema, err := LoadSchema(writeSchemaFile(t, `{
+		"keys": {
+			"API_URL":     {"required": true},
+			"DB_PORT":     {"default": "5432"},
+			"LOG_DIR":     {"default": ""},
+			"ENVIRONMENT": {"default": "DEVELOPMENT"},
+			"CACHE_DIR":   {}
+		}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"API_URL"}, schema.RequiredKeys())
+
+	// Defaults in the Go configuration take precedence over the schema.
+	config := schema.Apply(Config{Logger: logrus.New(), Defaults: map[string]string{"ENVIRONMENT": "STAGING"}, OptionalKeys: []string{"EXTRA"}})
+	assert.Equal(t, map[string]string{"DB_PORT": "5432", "LOG_DIR": "", "ENVIRONMENT": "STAGING"}, config.Defaults)
+	assert.Equal(t, []string{"EXTRA", "CACHE_DIR", "DB_PORT", "ENVIRONMENT", "LOG_DIR"}, config.OptionalKeys)
+
+	config.Logger.SetOutput(io.Discard)
+	envFilePath := createTempEnvFile(t, "API_URL=https://api.myapp.com/v1/\n")
+	values, err := NewValidator(config, schema.RequiredKeys()).Load(envFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, 5432, values.MustInt("DB_PORT"))
+	assert.True(t, values.Defaulted("DB_PORT"))
+	assert.Equal(t, "STAGING", values.MustString("ENVIRONMENT"))
+	assert.Equal(t, "", values.MustString("LOG_DIR"))
+
+	// Schema defaults are validated by the plugins like any other default.
+	invalid, err := LoadSchema(writeSchemaFile(t, `{"keys": {"ENVIRONMENT": {"default": "LOCAL"}}}`))
+	assert.NoError(t, err)
+	err = NewValidator(invalid.Apply(Config{Logger: config.Logger}), nil).ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid default value")
+}
+
+func TestLoadSchema_Errors(t *testing.T) {
+	_, err := LoadSchema(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	_, err = LoadSchema(writeSchemaFile(t, `{"keys": {"DB_PORT": {"defualt": "5432"}}}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "defualt"`)
+
+	_, err = LoadSchema(writeSchemaFile(t, `{"keys": {"API_URL": {"required": true, "default": "https://localhost"}}}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `key "API_URL" is required and cannot have a default`)
+}
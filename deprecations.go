@@ -0,0 +1,70 @@
+package validot
+
+import (
+	"fmt"
+
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// withDeprecations returns the entries with the value of each deprecated key that has
+// MapValue set copied onto its replacement, unless the replacement is set itself. The copy
+// follows the deprecated entry and keeps its line, so problems point at the deprecated key.
+//
+// Parameters:
+//   - entries: The entries parsed from the `.env` file.
+//
+// Returns:
+//   - []envEntry: The entries, including mapped replacements.
+//   - map[string]string: The deprecated key each mapped replacement takes its value from.
+func (v *Validator) withDeprecations(entries []envEntry) ([]envEntry, map[string]string) {
+	mapped := make(map[string]string)
+	if len(v.config.DeprecatedKeys) == 0 {
+		return entries, mapped
+	}
+
+	set := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		set[entry.Key] = true
+	}
+
+	withMapped := make([]envEntry, 0, len(entries))
+	for _, entry := range entries {
+		withMapped = append(withMapped, entry)
+		deprecated, ok := v.config.DeprecatedKeys[entry.Key]
+		if !ok || !deprecated.MapValue || deprecated.Replacement == "" || set[deprecated.Replacement] {
+			continue
+		}
+		replacement := entry
+		replacement.Key = deprecated.Replacement
+		withMapped = append(withMapped, replacement)
+		mapped[deprecated.Replacement] = entry.Key
+	}
+	return withMapped, mapped
+}
+
+// deprecationProblems returns the problems with a deprecated key: a warning that it is
+// deprecated and, if its replacement is also set in the file with a different value, an error.
+//
+// Parameters:
+//   - key: The key to check.
+//   - values: The values to validate, keyed by key.
+//   - inFile: The keys set in the `.env` file.
+//
+// Returns:
+//   - []error: The problems, or nil if the key is not deprecated. Warnings satisfy plugins.IsWarning.
+func (v *Validator) deprecationProblems(key string, values map[string]string, inFile map[string]bool) []error {
+	deprecated, ok := v.config.DeprecatedKeys[key]
+	if !ok || !inFile[key] {
+		return nil
+	}
+
+	if deprecated.Replacement == "" {
+		return []error{plugins.Warnf("key %q is deprecated", key)}
+	}
+
+	problems := []error{plugins.Warnf("key %q is deprecated; use %q instead", key, deprecated.Replacement)}
+	if inFile[deprecated.Replacement] && values[key] != values[deprecated.Replacement] {
+		problems = append(problems, fmt.Errorf("deprecated key %q and its replacement %q are both set with different values", key, deprecated.Replacement))
+	}
+	return problems
+}
@@ -0,0 +1,102 @@
+// deprecations_test.go
+package validot
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDeprecationValidator(logOutput io.Writer, mapValue bool) *Validator {
+	logger := logrus.New()
+	logger.SetOutput(logOutput)
+
+	return NewValidator(Config{
+		Verbose: true,
+		Logger:  logger,
+		Plugins: []plugins.ValidationPlugin{&plugins.HostValidationPlugin{Key: "CACHE_HOST", ForbidLocalhost: true}},
+		DeprecatedKeys: map[string]DeprecatedKey{
+			"REDIS_HOST":   {Replacement: "CACHE_HOST", MapValue: mapValue},
+			"LEGACY_MODE":  {},
+			"UNUSED_ALIAS": {Replacement: "CACHE_PORT"},
+		},
+	}, []string{"CACHE_HOST"})
+}
+
+func TestValidateDotEnv_DeprecatedKeyMappedOntoReplacement(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "REDIS_HOST=cache.internal\nLEGACY_MODE=1\n")
+
+	var logBuffer bytes.Buffer
+	validator := newDeprecationValidator(&logBuffer, true)
+
+	values, err := validator.Load(envFilePath)
+	assert.NoError(t, err, "The mapped value satisfies the required replacement")
+	assert.Equal(t, "cache.internal", values.MustString("CACHE_HOST"))
+
+	logs := logBuffer.String()
+	assert.Contains(t, logs, `Deprecation warning for key REDIS_HOST: key \"REDIS_HOST\" is deprecated; use \"CACHE_HOST\" instead`)
+	assert.Contains(t, logs, `Deprecation warning for key LEGACY_MODE: key \"LEGACY_MODE\" is deprecated`)
+	assert.Contains(t, logs, "CACHE_HOST is not set in the file; using the value of deprecated key REDIS_HOST.")
+	assert.Contains(t, logs, "[Validated by: HostValidationPlugin]")
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, envFilePath+`:1: warning: key "REDIS_HOST" is deprecated; use "CACHE_HOST" instead`, findings[0].String())
+		assert.Equal(t, envFilePath+`:2: warning: key "LEGACY_MODE" is deprecated`, findings[1].String())
+	}
+}
+
+func TestValidateDotEnv_MappedValueIsValidated(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "# Cache\nREDIS_HOST=localhost\n")
+	validator := newDeprecationValidator(io.Discard, true)
+
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `value for key "CACHE_HOST" must not be localhost`)
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+		assert.Equal(t, "CACHE_HOST", findings[1].Key)
+		assert.Equal(t, 2, findings[1].Line, "Problems in a mapped value point at the deprecated key")
+	}
+}
+
+func TestValidateDotEnv_DeprecatedKeyWithoutMapping(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "REDIS_HOST=cache.internal\n")
+	validator := newDeprecationValidator(io.Discard, false)
+
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required keys: [CACHE_HOST]")
+}
+
+func TestValidateDotEnv_DeprecatedAndReplacementBothSet(t *testing.T) {
+	validator := newDeprecationValidator(io.Discard, true)
+
+	// The same value in both keys is only a deprecation warning.
+	envFilePath := createTempEnvFile(t, "REDIS_HOST=cache.internal\nCACHE_HOST=cache.internal\n")
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+
+	envFilePath = createTempEnvFile(t, "REDIS_HOST=old.internal\nCACHE_HOST=cache.internal\n")
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `deprecated key "REDIS_HOST" and its replacement "CACHE_HOST" are both set with different values`)
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	var messages []string
+	for _, finding := range findings {
+		messages = append(messages, finding.Severity.String()+": "+finding.Message)
+	}
+	assert.Equal(t, []string{
+		`warning: key "REDIS_HOST" is deprecated; use "CACHE_HOST" instead`,
+		`error: deprecated key "REDIS_HOST" and its replacement "CACHE_HOST" are both set with different values`,
+	}, messages)
+}
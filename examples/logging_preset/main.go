@@ -0,0 +1,31 @@
+// examples/logging_preset/main.go
+package main
+
+import (
+	"github.com/mwiater/go-validot"
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	// Define required keys
+	requiredKeys := []string{"APP_NAME", "LOG_LEVEL"}
+
+	// Initialize a custom logger
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.TextFormatter{
+		FullTimestamp: true,
+	})
+	logger.SetLevel(logrus.InfoLevel) // Default log level
+
+	// Create a new validator
+	validator := validot.NewValidator(validot.Config{
+		RequireQuotes: true,                                             // Enforce that values must be quoted
+		Verbose:       true,                                             // Enable verbose logging
+		Logger:        logger,                                           // Use the custom logger
+		Plugins:       plugins.LoggingPreset("LOG_LEVEL", "LOG_FORMAT"), // Validate LOG_LEVEL and LOG_FORMAT alongside the built-in plugins
+	}, requiredKeys)
+
+	// Validate the .env file
+	_ = validator.ValidateDotEnv(".env") // No need to log success here
+}
@@ -0,0 +1,193 @@
+package validot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// Severity indicates whether a finding fails validation.
+type Severity int
+
+const (
+	// SeverityError marks a problem that fails validation. This is the default.
+	SeverityError Severity = iota
+	// SeverityWarning marks a problem that is reported but does not fail validation.
+	SeverityWarning
+)
+
+// String returns a human-readable name for the severity.
+//
+// Returns:
+//   - string: The name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// Finding describes a single validation problem found in a `.env` file.
+type Finding struct {
+	File      string   // The path to the `.env` file containing the problem.
+	Line      int      // The 1-based line number of the key, or 0 if the problem has no location (e.g. a missing key).
+	Key       string   // The key the problem applies to, if any.
+	Plugin    string   // The name of the plugin that reported the problem, if any.
+	Rule      string   // The name of the LintRule that reported the problem, if any.
+	Message   string   // A description of the problem.
+	Severity  Severity // Whether the problem fails validation; plugins report warnings with plugins.Warnf.
+	Defaulted bool     // Whether the problem is in a value from Config.Defaults rather than the file.
+}
+
+// String formats the finding as `file:line: message`, omitting the line if it is unknown.
+// Warnings are prefixed with "warning: ", problems in default values are suffixed with
+// " (defaulted)", and lint problems are suffixed with their rule, e.g. " [crlf]".
+//
+// Returns:
+//   - string: The formatted finding.
+func (f Finding) String() string {
+	message := f.Message
+	if f.Severity == SeverityWarning {
+		message = "warning: " + message
+	}
+	if f.Defaulted {
+		message += " (defaulted)"
+	}
+	if f.Rule != "" {
+		message += " [" + f.Rule + "]"
+	}
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", f.File, f.Line, message)
+	}
+	return fmt.Sprintf("%s: %s", f.File, message)
+}
+
+// identity returns the parts of the finding that identify the underlying problem,
+// ignoring its line number so that a problem is not reported again when lines move.
+func (f Finding) identity() string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%s\x00%t\x00%s", f.File, f.Key, f.Plugin, f.Rule, f.Severity, f.Defaulted, f.Message)
+}
+
+// Findings validates the `.env` file at the specified path and returns every problem
+// found, rather than stopping at the first one like ValidateDotEnv does. Nothing is logged.
+//
+// Parameters:
+//   - filePath: The path to the `.env` file to validate.
+//
+// Returns:
+//   - []Finding: The problems found: key naming violations in file order, then problems in key order, then missing required keys.
+//   - error: An error if the `.env` file could not be read or parsed, or if Config.KeyNaming has an invalid pattern.
+func (v *Validator) Findings(filePath string) ([]Finding, error) {
+	return v.FindingsContext(context.Background(), filePath)
+}
+
+// FindingsContext is like Findings, but stops when the context is canceled or its deadline passes.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - filePath: The path to the `.env` file to validate.
+//
+// Returns:
+//   - []Finding: The problems found: key naming violations in file order, then problems in key order, then missing required keys.
+//   - error: An error if the `.env` file could not be read or parsed, an error if Config.KeyNaming has an invalid pattern, or a *CanceledError if the context is done.
+func (v *Validator) FindingsContext(ctx context.Context, filePath string) ([]Finding, error) {
+	entries, err := loadEnvFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	violations, err := checkKeyNames(entries, v.config.KeyNaming)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, violation := range violations {
+		findings = append(findings, Finding{File: filePath, Line: violation.Line, Key: violation.Key, Message: violation.Message})
+	}
+
+	inFile := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		inFile[entry.Key] = true
+	}
+	entries, _ = v.withDeprecations(entries)
+	entries, defaulted := v.withDefaults(entries)
+	envVars, err := resolveEnvValues(entries, v.config.Interpolation, v.lookupEnv())
+	if err != nil {
+		return append(findings, Finding{File: filePath, Message: err.Error()}), nil
+	}
+
+	lines := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		lines[entry.Key] = entry.Line
+	}
+
+	keys := v.orderedKeys(entries)
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !defaulted[key] {
+			seen[key] = true
+		}
+	}
+
+	results := v.checkKeys(pluginContext(ctx, filePath, envVars), keys, envVars, false)
+	if err := ctx.Err(); err != nil {
+		return nil, &CanceledError{Unchecked: uncheckedKeys(keys, results), Err: err}
+	}
+
+	for i, key := range keys {
+		for _, problem := range v.deprecationProblems(key, envVars, inFile) {
+			findings = append(findings, keyFinding(filePath, lines[key], key, problem))
+		}
+		for _, outcome := range results[i].outcomes {
+			if outcome.err != nil {
+				finding := Finding{
+					File:      filePath,
+					Line:      lines[key],
+					Key:       key,
+					Plugin:    outcome.plugin,
+					Message:   outcome.err.Error(),
+					Defaulted: defaulted[key],
+				}
+				if plugins.IsWarning(outcome.err) {
+					finding.Severity = SeverityWarning
+				}
+				findings = append(findings, finding)
+			}
+		}
+		if problem := v.unknownKeyProblem(key, results[i]); problem != nil {
+			findings = append(findings, keyFinding(filePath, lines[key], key, problem))
+		}
+	}
+
+	for _, key := range v.missingKeys(seen) {
+		findings = append(findings, Finding{
+			File:    filePath,
+			Key:     key,
+			Message: fmt.Sprintf("missing required key: %s", key),
+		})
+	}
+
+	return findings, nil
+}
+
+// keyFinding returns a finding for a problem with a key that is not reported by a plugin,
+// such as a deprecated or unknown key.
+//
+// Parameters:
+//   - filePath: The path to the `.env` file.
+//   - line: The line number of the key.
+//   - key: The key the problem applies to.
+//   - problem: The problem; warnings (see plugins.IsWarning) produce SeverityWarning findings.
+//
+// Returns:
+//   - Finding: The finding.
+func keyFinding(filePath string, line int, key string, problem error) Finding {
+	finding := Finding{File: filePath, Line: line, Key: key, Message: problem.Error()}
+	if plugins.IsWarning(problem) {
+		finding.Severity = SeverityWarning
+	}
+	return finding
+}
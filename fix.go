@@ -0,0 +1,189 @@
+package validot
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// FixResult describes the changes made to a `.env` file by Validator.Fix.
+type FixResult struct {
+	FilePath string // The path to the `.env` file that was fixed.
+	Original []byte // The contents of the file before fixes were applied.
+	Fixed    []byte // The contents of the file after fixes were applied.
+}
+
+// Changed reports whether any fixes were applied.
+//
+// Returns:
+//   - bool: True if the fixed contents differ from the original contents.
+func (r *FixResult) Changed() bool {
+	return !bytes.Equal(r.Original, r.Fixed)
+}
+
+// Diff returns a unified diff of the changes made by the fixes.
+//
+// Returns:
+//   - string: The unified diff, or an empty string if nothing changed.
+func (r *FixResult) Diff() string {
+	return unifiedDiff(r.FilePath, r.Original, r.Fixed)
+}
+
+// WriteFile writes the fixed contents back to the `.env` file, preserving its permissions.
+//
+// Returns:
+//   - error: An error if the file could not be written.
+func (r *FixResult) WriteFile() error {
+	if !r.Changed() {
+		return nil
+	}
+	info, err := os.Stat(r.FilePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.FilePath, r.Fixed, info.Mode().Perm())
+}
+
+// Fix rewrites the `.env` file at the specified path to correct mechanical formatting
+// problems: unquoted values when `RequireQuotes` is set, values that a plugin implementing
+// plugins.FixerPlugin can canonicalize (such as booleans and enum casing), and trailing
+// whitespace. Comments, ordering and blank lines are preserved. The file itself is not
+// modified; call WriteFile on the result to apply the fixes.
+//
+// Parameters:
+//   - filePath: The path to the `.env` file to fix.
+//
+// Returns:
+//   - *FixResult: The original and fixed contents of the file.
+//   - error: An error if the file could not be read or parsed.
+func (v *Validator) Fix(filePath string) (*FixResult, error) {
+	v.ensureLogger()
+
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+	entries, err := parseEnv(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	var out bytes.Buffer
+	last := 0
+	for _, entry := range entries {
+		original := string(src[entry.Start:entry.End])
+		fixed := v.fixValue(entry, original)
+		if fixed == original {
+			continue
+		}
+		if v.config.Verbose {
+			v.config.Logger.Infof("Fixed value for key %s on line %d", entry.Key, entry.Line)
+		}
+		out.Write(src[last:entry.Start])
+		out.WriteString(fixed)
+		last = entry.End
+	}
+	out.Write(src[last:])
+
+	fixedEntries, err := parseEnv(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("fixed .env file no longer parses: %w", err)
+	}
+
+	return &FixResult{
+		FilePath: filePath,
+		Original: src,
+		Fixed:    trimTrailingWhitespace(out.Bytes(), fixedEntries),
+	}, nil
+}
+
+// fixValue applies plugin fixes and quoting rules to a single entry.
+//
+// Parameters:
+//   - entry: The parsed entry to fix.
+//   - original: The value exactly as written in the file, including quotes.
+//
+// Returns:
+//   - string: The value as it should be written in the file.
+func (v *Validator) fixValue(entry envEntry, original string) string {
+	value := entry.Value
+
+	// Values containing references are validated after expansion, so rewriting them is unsafe.
+	if entry.Quote == prefixSingleQuote || !strings.Contains(value, "$") {
+		for _, plugin := range v.plugins {
+			if fixer, ok := plugin.(plugins.FixerPlugin); ok {
+				if fixed, changed := fixer.Fix(entry.Key, value); changed {
+					value = fixed
+				}
+			}
+		}
+	}
+
+	quote := entry.Quote
+	if quote == 0 && v.config.RequireQuotes {
+		quote = prefixDoubleQuote
+	}
+	if value == entry.Value && quote == entry.Quote {
+		return original
+	}
+
+	return quoteValue(value, quote)
+}
+
+// quoteValue formats a value for writing to a `.env` file using the given quote character.
+//
+// Parameters:
+//   - value: The value to format.
+//   - quote: The quote character to use, or 0 to leave the value unquoted.
+//
+// Returns:
+//   - string: The formatted value.
+func quoteValue(value string, quote byte) string {
+	switch {
+	case quote == 0:
+		return value
+	case quote == prefixSingleQuote && !strings.ContainsRune(value, prefixSingleQuote):
+		return "'" + value + "'"
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// trimTrailingWhitespace removes spaces and tabs at the end of each line, leaving line
+// endings intact and skipping lines that end inside a multi-line quoted value.
+//
+// Parameters:
+//   - src: The contents of the `.env` file.
+//   - entries: The entries parsed from src, used to locate multi-line values.
+//
+// Returns:
+//   - []byte: The contents without trailing whitespace.
+func trimTrailingWhitespace(src []byte, entries []envEntry) []byte {
+	var out bytes.Buffer
+	pos := 0
+	for _, line := range splitLines(src) {
+		content := strings.TrimRight(line, "\r\n")
+		ending := line[len(content):]
+		lineEnd := pos + len(content)
+		pos += len(line)
+
+		insideValue := false
+		for _, entry := range entries {
+			if entry.Quote != 0 && entry.Start < lineEnd && lineEnd < entry.End {
+				insideValue = true
+				break
+			}
+		}
+
+		if !insideValue {
+			content = strings.TrimRight(content, " \t")
+		}
+		out.WriteString(content)
+		out.WriteString(ending)
+	}
+	return out.Bytes()
+}
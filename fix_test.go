@@ -0,0 +1,84 @@
+// fix_test.go
+package validot
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFix_RewritesMechanicalProblems(t *testing.T) {
+	envContent := "# Settings\n" +
+		"API_URL=https://api.myapp.com/v1/   \n" +
+		"\n" +
+		"ENVIRONMENT=production # deployment stage\n" +
+		"ENABLE_DEBUG='yes'\n" +
+		"GREETING=\"multi   \n" +
+		"line\"\n" +
+		"DB_ADDR=${API_URL}\n"
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		RequireQuotes: true,
+		Logger:        logger,
+	}, nil)
+
+	result, err := validator.Fix(envFilePath)
+	assert.NoError(t, err)
+	assert.True(t, result.Changed())
+
+	expected := "# Settings\n" +
+		"API_URL=\"https://api.myapp.com/v1/\"\n" +
+		"\n" +
+		"ENVIRONMENT=\"PRODUCTION\" # deployment stage\n" +
+		"ENABLE_DEBUG='true'\n" +
+		"GREETING=\"multi   \n" +
+		"line\"\n" +
+		"DB_ADDR=\"${API_URL}\"\n"
+	assert.Equal(t, expected, string(result.Fixed))
+
+	// Fix does not modify the file until WriteFile is called.
+	onDisk, _ := os.ReadFile(envFilePath)
+	assert.Equal(t, envContent, string(onDisk))
+
+	assert.NoError(t, result.WriteFile())
+	onDisk, _ = os.ReadFile(envFilePath)
+	assert.Equal(t, expected, string(onDisk))
+
+	// The fixed file validates and a second run makes no changes.
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+	result, err = validator.Fix(envFilePath)
+	assert.NoError(t, err)
+	assert.False(t, result.Changed())
+	assert.Empty(t, result.Diff())
+}
+
+func TestFix_Diff(t *testing.T) {
+	result := &FixResult{
+		FilePath: ".env",
+		Original: []byte("A=1\nB=2\nC=3\nD=4\nE=5\nF=6\nG=7\nH=8\nI=9\nJ=10\n"),
+		Fixed:    []byte("A=1\nB=2\nC=3\nD=4\nE=5\nF=six\nG=7\nH=8\nI=9\nJ=10\n"),
+	}
+
+	expected := "--- .env.orig\n" +
+		"+++ .env\n" +
+		"@@ -3,7 +3,7 @@\n" +
+		" C=3\n" +
+		" D=4\n" +
+		" E=5\n" +
+		"-F=6\n" +
+		"+F=six\n" +
+		" G=7\n" +
+		" H=8\n" +
+		" I=9\n"
+	assert.Equal(t, expected, result.Diff())
+}
@@ -0,0 +1,61 @@
+// Package suggest finds close matches for misspelled names and values, for use in
+// "did you mean" hints.
+package suggest
+
+import "strings"
+
+// Distance returns the Levenshtein edit distance between a and b, counting
+// insertions, deletions and substitutions of runes.
+//
+// Parameters:
+//   - a: The first string.
+//   - b: The second string.
+//
+// Returns:
+//   - int: The minimum number of single-rune edits that turn a into b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// Closest returns the candidate closest to target, compared case-insensitively. A
+// candidate is only suggested if it is within a third of the target's length in edits
+// (at least one edit), so unrelated values do not produce misleading hints. Ties are
+// resolved in favor of the earlier candidate.
+//
+// Parameters:
+//   - target: The misspelled name or value.
+//   - candidates: The valid names or values.
+//
+// Returns:
+//   - string: The closest candidate, if any.
+//   - bool: True if a candidate is close enough to suggest.
+func Closest(target string, candidates []string) (string, bool) {
+	limit := max(len([]rune(target))/3, 1)
+	best, bestDistance := "", limit+1
+
+	lower := strings.ToLower(target)
+	for _, candidate := range candidates {
+		if d := Distance(lower, strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best, bestDistance <= limit
+}
@@ -0,0 +1,121 @@
+package validot
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// expandVarRegex matches `$KEY` and `${KEY}` references, along with escaped (`\$KEY`)
+// and command-style (`$(...)`) forms, which are not expanded.
+var expandVarRegex = regexp.MustCompile(`(\\)?(\$)(\()?\{?([A-Z0-9_]+)?\}?`)
+
+// interpolator resolves `${KEY}` references between the entries of a `.env` file.
+// References may point forward or backward in the file. References to keys the file
+// does not define fall back to the environment, if lookupEnv is set; references to keys
+// that are not defined anywhere and reference cycles are reported as errors.
+type interpolator struct {
+	entries   map[string]envEntry             // The last definition of each key in the file.
+	lookupEnv func(key string) (string, bool) // Looks up keys the file does not define, e.g. os.LookupEnv; nil disables the fallback.
+	resolved  map[string]string               // The expanded values resolved so far.
+	stack     []string                        // The keys currently being resolved, used for cycle detection.
+}
+
+// resolveEnvValues checks the references between the parsed entries and returns the
+// values that should be handed to the validation plugins.
+//
+// Parameters:
+//   - entries: The entries parsed from the `.env` file.
+//   - mode: Whether plugins should see the expanded or the raw values.
+//   - lookupEnv: Looks up referenced keys the file does not define, e.g. os.LookupEnv; nil disables the fallback.
+//
+// Returns:
+//   - map[string]string: A map of keys to the values to validate.
+//   - error: An error if a reference is undefined or part of a cycle.
+func resolveEnvValues(entries []envEntry, mode InterpolationMode, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	in := &interpolator{
+		entries:   make(map[string]envEntry, len(entries)),
+		lookupEnv: lookupEnv,
+		resolved:  make(map[string]string, len(entries)),
+	}
+	for _, entry := range entries {
+		in.entries[entry.Key] = entry
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		expanded, err := in.resolve(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if mode == InterpolateRaw {
+			values[entry.Key] = in.entries[entry.Key].Value
+		} else {
+			values[entry.Key] = expanded
+		}
+	}
+
+	return values, nil
+}
+
+// resolve returns the fully expanded value of the given key.
+//
+// Parameters:
+//   - key: The key whose value should be expanded.
+//
+// Returns:
+//   - string: The expanded value.
+//   - error: An error if the value references an undefined key or is part of a cycle.
+func (in *interpolator) resolve(key string) (string, error) {
+	if value, ok := in.resolved[key]; ok {
+		return value, nil
+	}
+
+	for i, k := range in.stack {
+		if k == key {
+			cycle := append(append([]string{}, in.stack[i:]...), key)
+			return "", fmt.Errorf("interpolation cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	entry := in.entries[key]
+	if entry.Quote == prefixSingleQuote {
+		in.resolved[key] = entry.Value
+		return entry.Value, nil
+	}
+
+	in.stack = append(in.stack, key)
+	defer func() { in.stack = in.stack[:len(in.stack)-1] }()
+
+	var resolveErr error
+	expanded := expandVarRegex.ReplaceAllStringFunc(entry.Value, func(match string) string {
+		submatch := expandVarRegex.FindStringSubmatch(match)
+		if submatch[1] == `\` {
+			return match[1:]
+		}
+		ref := submatch[4]
+		if ref == "" || submatch[3] == "(" || resolveErr != nil {
+			return match
+		}
+		if _, ok := in.entries[ref]; !ok {
+			if in.lookupEnv != nil {
+				if value, ok := in.lookupEnv(ref); ok {
+					return value // Values from the environment are used as they are, without expansion.
+				}
+			}
+			resolveErr = fmt.Errorf("value for key %q references undefined key %q", key, ref)
+			return match
+		}
+		value, err := in.resolve(ref)
+		if err != nil {
+			resolveErr = err
+		}
+		return value
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	in.resolved[key] = expanded
+	return expanded, nil
+}
@@ -0,0 +1,241 @@
+package validot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// utf8BOM is the UTF-8 encoding of the byte order mark U+FEFF.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// LintRule identifies a syntax problem reported by Validator.Lint. Each rule flags input
+// that some loaders accept but others do not, so that a `.env` file reads the same with
+// docker `--env-file`, a POSIX shell and godotenv.
+type LintRule int
+
+const (
+	// LintExportPrefix reports assignments with an `export` prefix, which docker `--env-file` rejects.
+	LintExportPrefix LintRule = iota
+	// LintSpacesAroundEquals reports spaces around `=`, which docker `--env-file` and shells reject.
+	LintSpacesAroundEquals
+	// LintCRLF reports Windows (CRLF) line endings, which leave a carriage return at the end of values.
+	LintCRLF
+	// LintBOM reports a UTF-8 byte order mark, which becomes part of the first key.
+	LintBOM
+	// LintTrailingWhitespace reports spaces and tabs at the end of a line.
+	LintTrailingWhitespace
+	// LintUnterminatedQuote reports a quoted value without a closing quote.
+	LintUnterminatedQuote
+	// LintInvalidUTF8 reports lines that are not valid UTF-8.
+	LintInvalidUTF8
+	// LintSyntax reports other syntax problems, such as invalid characters in a key.
+	LintSyntax
+)
+
+// LintRules lists every lint rule, in the order they are documented.
+var LintRules = []LintRule{
+	LintExportPrefix, LintSpacesAroundEquals, LintCRLF, LintBOM,
+	LintTrailingWhitespace, LintUnterminatedQuote, LintInvalidUTF8, LintSyntax,
+}
+
+// String returns the name of the lint rule, as shown in findings and accepted by the CLI.
+//
+// Returns:
+//   - string: The name of the lint rule.
+func (r LintRule) String() string {
+	switch r {
+	case LintExportPrefix:
+		return "export-prefix"
+	case LintSpacesAroundEquals:
+		return "spaces-around-equals"
+	case LintCRLF:
+		return "crlf"
+	case LintBOM:
+		return "bom"
+	case LintTrailingWhitespace:
+		return "trailing-whitespace"
+	case LintUnterminatedQuote:
+		return "unterminated-quote"
+	case LintInvalidUTF8:
+		return "invalid-utf8"
+	case LintSyntax:
+		return "syntax"
+	default:
+		return fmt.Sprintf("LintRule(%d)", int(r))
+	}
+}
+
+// LintLevel controls how a lint rule is reported.
+type LintLevel int
+
+const (
+	// LintLevelError reports the rule as an error. This is the default.
+	LintLevelError LintLevel = iota
+	// LintLevelWarning reports the rule as a warning.
+	LintLevelWarning
+	// LintLevelOff disables the rule.
+	LintLevelOff
+)
+
+// String returns a human-readable name for the lint level.
+//
+// Returns:
+//   - string: The name of the lint level.
+func (l LintLevel) String() string {
+	switch l {
+	case LintLevelWarning:
+		return "warning"
+	case LintLevelOff:
+		return "off"
+	default:
+		return "error"
+	}
+}
+
+// Lint checks the syntax of the `.env` file at the specified path and returns a finding
+// for each problem reported by a LintRule, at the level configured in Config.Lint. Unlike
+// Findings, the values are not validated; the file is linted even if it does not parse.
+// Nothing is logged.
+//
+// Parameters:
+//   - filePath: The path to the `.env` file to lint.
+//
+// Returns:
+//   - []Finding: The problems found, in line order, with Finding.Rule set.
+//   - error: An error if the `.env` file could not be read.
+func (v *Validator) Lint(filePath string) ([]Finding, error) {
+	return v.LintContext(context.Background(), filePath)
+}
+
+// LintContext is like Lint, but stops when the context is canceled or its deadline passes.
+//
+// Parameters:
+//   - ctx: The context for the lint.
+//   - filePath: The path to the `.env` file to lint.
+//
+// Returns:
+//   - []Finding: The problems found, in line order, with Finding.Rule set.
+//   - error: An error if the `.env` file could not be read, or the context's error if it is done.
+func (v *Validator) LintContext(ctx context.Context, filePath string) ([]Finding, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	report := func(rule LintRule, line int, key, format string, args ...any) {
+		level := v.config.Lint[rule]
+		if level == LintLevelOff {
+			return
+		}
+		finding := Finding{File: filePath, Line: line, Key: key, Rule: rule.String(), Message: fmt.Sprintf(format, args...)}
+		if level == LintLevelWarning {
+			finding.Severity = SeverityWarning
+		}
+		findings = append(findings, finding)
+	}
+
+	// The byte order mark is reported and skipped, so that it does not also fail the first key.
+	if bytes.HasPrefix(src, utf8BOM) {
+		report(LintBOM, 1, "", "file starts with a UTF-8 byte order mark")
+		src = src[len(utf8BOM):]
+	}
+
+	entries, err := parseEnv(src)
+	var syntaxErr *syntaxError
+	switch {
+	case errors.Is(err, errUnterminatedQuote) && errors.As(err, &syntaxErr):
+		report(LintUnterminatedQuote, syntaxErr.Line, "", "%v", syntaxErr.Err)
+	case errors.As(err, &syntaxErr):
+		report(LintSyntax, syntaxErr.Line, "", "%v", syntaxErr.Err)
+	}
+
+	for _, entry := range entries {
+		lintAssignment(src[entry.Offset:entry.Start], entry, report)
+	}
+	lintLines(src, entries, report)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+// lintAssignment checks the part of an assignment before its value for an `export` prefix
+// and spaces around the separator.
+//
+// Parameters:
+//   - statement: The assignment as written, from its start up to its value.
+//   - entry: The parsed entry.
+//   - report: Records a problem.
+func lintAssignment(statement []byte, entry envEntry, report func(LintRule, int, string, string, ...any)) {
+	text := string(statement)
+	if rest := strings.TrimPrefix(text, exportPrefix); rest != text && strings.TrimLeft(rest, " \t") != rest {
+		report(LintExportPrefix, entry.Line, entry.Key, "key %q has an %q prefix", entry.Key, exportPrefix)
+		text = strings.TrimLeft(rest, " \t")
+	}
+
+	separator := strings.IndexAny(text, "=:")
+	if separator < 0 {
+		return
+	}
+	spaceBefore := strings.TrimRight(text[:separator], " \t") != text[:separator]
+	// Spaces after the separator of an empty value are trailing whitespace instead.
+	spaceAfter := separator+1 < len(text) && entry.End > entry.Start
+	if spaceBefore || spaceAfter {
+		report(LintSpacesAroundEquals, entry.Line, entry.Key, "key %q has spaces around %q", entry.Key, text[separator:separator+1])
+	}
+}
+
+// lintLines checks each line for CRLF line endings, trailing whitespace and invalid UTF-8.
+// CRLF line endings are reported once, on the first line that has one.
+//
+// Parameters:
+//   - src: The contents of the `.env` file, without a byte order mark.
+//   - entries: The entries parsed from src, used to skip lines inside multi-line values.
+//   - report: Records a problem.
+func lintLines(src []byte, entries []envEntry, report func(LintRule, int, string, string, ...any)) {
+	crlfLine, crlfCount := 0, 0
+	pos := 0
+	for i, line := range splitLines(src) {
+		number := i + 1
+		content := strings.TrimSuffix(line, "\n")
+		if strings.HasSuffix(content, "\r") {
+			content = strings.TrimSuffix(content, "\r")
+			if crlfCount == 0 {
+				crlfLine = number
+			}
+			crlfCount++
+		}
+		lineEnd := pos + len(content)
+		pos += len(line)
+
+		if !utf8.ValidString(line) {
+			report(LintInvalidUTF8, number, "", "line is not valid UTF-8")
+		}
+
+		insideValue := false
+		for _, entry := range entries {
+			if entry.Quote != 0 && entry.Start < lineEnd && lineEnd < entry.End {
+				insideValue = true
+				break
+			}
+		}
+		if !insideValue && strings.TrimRight(content, " \t") != content {
+			report(LintTrailingWhitespace, number, "", "line has trailing whitespace")
+		}
+	}
+
+	if crlfCount > 0 {
+		report(LintCRLF, crlfLine, "", "file has CRLF line endings on %d line(s); use LF", crlfCount)
+	}
+}
@@ -0,0 +1,90 @@
+// lint_test.go
+package validot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lintMessages(t *testing.T, validator *Validator, content string) []string {
+	t.Helper()
+	envFilePath := createTempEnvFile(t, content)
+
+	findings, err := validator.Lint(envFilePath)
+	assert.NoError(t, err)
+	var messages []string
+	for _, finding := range findings {
+		assert.Equal(t, envFilePath, finding.File)
+		messages = append(messages, finding.String()[len(envFilePath):])
+	}
+	return messages
+}
+
+func TestLint_CleanFile(t *testing.T) {
+	validator := NewValidator(Config{}, nil)
+
+	assert.Empty(t, lintMessages(t, validator, "# comment\nA=1\nB=\"two words\"\nC=\nMULTI=\"first  \nsecond\"\n"))
+}
+
+func TestLint_Rules(t *testing.T) {
+	validator := NewValidator(Config{}, nil)
+
+	content := "\xef\xbb\xbfexport A=1\r\n" +
+		"B = 2  \r\n" +
+		"C=\"x  \nok\"  \n" +
+		"D=\xff\n" +
+		"exported=3\n" +
+		"E=\"open\n"
+	assert.Equal(t, []string{
+		`:1: file starts with a UTF-8 byte order mark [bom]`,
+		`:1: key "A" has an "export" prefix [export-prefix]`,
+		`:1: file has CRLF line endings on 2 line(s); use LF [crlf]`,
+		`:2: key "B" has spaces around "=" [spaces-around-equals]`,
+		`:2: line has trailing whitespace [trailing-whitespace]`,
+		`:4: line has trailing whitespace [trailing-whitespace]`,
+		`:5: line is not valid UTF-8 [invalid-utf8]`,
+		`:7: unterminated quoted value "open [unterminated-quote]`,
+	}, lintMessages(t, validator, content))
+}
+
+func TestLint_SpacesAroundSeparator(t *testing.T) {
+	validator := NewValidator(Config{}, nil)
+
+	assert.Equal(t, []string{
+		`:1: key "A" has spaces around "=" [spaces-around-equals]`,
+		`:2: key "B" has spaces around ":" [spaces-around-equals]`,
+		// Spaces after the separator of an empty value are only trailing whitespace.
+		`:3: line has trailing whitespace [trailing-whitespace]`,
+	}, lintMessages(t, validator, "A= 1\nB :2\nC=  \n"))
+}
+
+func TestLint_Syntax(t *testing.T) {
+	validator := NewValidator(Config{}, nil)
+
+	assert.Equal(t, []string{
+		`:1: key "A" has an "export" prefix [export-prefix]`,
+		`:2: unexpected character "-" in variable name near "BAD-KEY=2" [syntax]`,
+	}, lintMessages(t, validator, "export A=1\nBAD-KEY=2\n"))
+}
+
+func TestLint_Levels(t *testing.T) {
+	validator := NewValidator(Config{Lint: map[LintRule]LintLevel{
+		LintExportPrefix:       LintLevelOff,
+		LintTrailingWhitespace: LintLevelWarning,
+	}}, nil)
+	envFilePath := createTempEnvFile(t, "export A=1 \n")
+
+	findings, err := validator.Lint(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, "trailing-whitespace", findings[0].Rule)
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+		assert.Equal(t, 1, findings[0].Line)
+	}
+}
+
+func TestLint_MissingFile(t *testing.T) {
+	_, err := NewValidator(Config{}, nil).Lint("does-not-exist.env")
+	assert.Error(t, err)
+}
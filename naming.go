@@ -0,0 +1,122 @@
+package validot
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// KeyStyle is a case style for key names.
+type KeyStyle int
+
+const (
+	// KeyStyleAny allows keys in any case style. This is the default.
+	KeyStyleAny KeyStyle = iota
+	// KeyStyleUpperSnake requires UPPER_SNAKE_CASE keys, such as `DB_HOST`.
+	KeyStyleUpperSnake
+	// KeyStyleLowerSnake requires lower_snake_case keys, such as `db_host`.
+	KeyStyleLowerSnake
+)
+
+// String returns a human-readable name for the key style.
+//
+// Returns:
+//   - string: The name of the key style.
+func (s KeyStyle) String() string {
+	switch s {
+	case KeyStyleUpperSnake:
+		return "UPPER_SNAKE_CASE"
+	case KeyStyleLowerSnake:
+		return "lower_snake_case"
+	default:
+		return "any"
+	}
+}
+
+// keyStylePatterns match the key styles: words of letters and digits separated by single
+// underscores, starting with a letter.
+var keyStylePatterns = map[KeyStyle]*regexp.Regexp{
+	KeyStyleUpperSnake: regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`),
+	KeyStyleLowerSnake: regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+}
+
+// ReservedKeyNames lists environment variables that are set by the operating system or
+// shell and that an application's `.env` file should not override. It can be used as
+// KeyNamingRules.ReservedNames.
+var ReservedKeyNames = []string{
+	"HOME", "HOSTNAME", "IFS", "LANG", "LD_LIBRARY_PATH", "LD_PRELOAD",
+	"PATH", "PWD", "SHELL", "TERM", "TMPDIR", "USER",
+}
+
+// KeyNamingRules are document-level rules for the names of the keys in a `.env` file. Unlike
+// plugins, which validate values, they apply to every key in the file and are reported with
+// the line the key is on. The zero value enforces no rules.
+type KeyNamingRules struct {
+	Style         KeyStyle // The case style every key must follow; KeyStyleAny allows any.
+	Prefix        string   // A prefix every key must start with, e.g. "PAYMENTS_". Optional.
+	Pattern       string   // A regular expression every key must match, e.g. `^(PAYMENTS|SHARED)_`. Optional.
+	MaxLength     int      // The maximum length of a key; 0 means no limit.
+	ReservedNames []string // Names that must not be used as keys, compared case-insensitively, e.g. ReservedKeyNames.
+}
+
+// keyNameViolation is a key that breaks a KeyNamingRules rule.
+type keyNameViolation struct {
+	Line    int    // The 1-based line number of the key.
+	Key     string // The key.
+	Message string // A description of the broken rule.
+}
+
+// Error formats the violation with its line number.
+//
+// Returns:
+//   - string: The formatted violation.
+func (v keyNameViolation) Error() string {
+	return fmt.Sprintf("line %d: %s", v.Line, v.Message)
+}
+
+// checkKeyNames checks every entry against the rules.
+//
+// Parameters:
+//   - entries: The entries parsed from the `.env` file.
+//   - rules: The naming rules.
+//
+// Returns:
+//   - []keyNameViolation: The violations, in file order; a key breaking several rules has several.
+//   - error: An error if the rules' Pattern is not a valid regular expression.
+func checkKeyNames(entries []envEntry, rules KeyNamingRules) ([]keyNameViolation, error) {
+	var pattern *regexp.Regexp
+	if rules.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(rules.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid key naming pattern %q: %v", rules.Pattern, err)
+		}
+	}
+
+	var violations []keyNameViolation
+	for _, entry := range entries {
+		report := func(format string, args ...any) {
+			violations = append(violations, keyNameViolation{Line: entry.Line, Key: entry.Key, Message: fmt.Sprintf(format, args...)})
+		}
+
+		key := entry.Key
+		if style, ok := keyStylePatterns[rules.Style]; ok && !style.MatchString(key) {
+			report("key %q must be %s", key, rules.Style)
+		}
+		if rules.Prefix != "" && !strings.HasPrefix(key, rules.Prefix) {
+			report("key %q must start with %q", key, rules.Prefix)
+		}
+		if pattern != nil && !pattern.MatchString(key) {
+			report("key %q must match %q", key, rules.Pattern)
+		}
+		if rules.MaxLength > 0 && len(key) > rules.MaxLength {
+			report("key %q must be at most %d characters, got %d", key, rules.MaxLength, len(key))
+		}
+		for _, reserved := range rules.ReservedNames {
+			if strings.EqualFold(key, reserved) {
+				report("key %q is reserved and must not be used", key)
+				break
+			}
+		}
+	}
+	return violations, nil
+}
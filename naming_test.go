@@ -0,0 +1,105 @@
+// naming_test.go
+package validot
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const namingEnv = "PAYMENTS_API_URL=https://api.myapp.com/v1/\n" +
+	"# comment\n" +
+	"payments_db_host=db.internal\n" +
+	"PAYMENTS_DB__PORT=5432\n" +
+	"PATH=/usr/bin\n" +
+	"PAYMENTS_A_VERY_LONG_KEY_NAME=1\n"
+
+func newNamingValidator(rules KeyNamingRules) *Validator {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return NewValidator(Config{Logger: logger, KeyNaming: rules}, nil)
+}
+
+func TestValidateDotEnv_KeyNamingOffByDefault(t *testing.T) {
+	envFilePath := createTempEnvFile(t, namingEnv)
+
+	assert.NoError(t, newNamingValidator(KeyNamingRules{}).ValidateDotEnv(envFilePath))
+}
+
+func TestValidateDotEnv_KeyNaming(t *testing.T) {
+	envFilePath := createTempEnvFile(t, namingEnv)
+	validator := newNamingValidator(KeyNamingRules{
+		Style:         KeyStyleUpperSnake,
+		Prefix:        "PAYMENTS_",
+		MaxLength:     24,
+		ReservedNames: ReservedKeyNames,
+	})
+
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Equal(t, `line 3: key "payments_db_host" must be UPPER_SNAKE_CASE
+line 3: key "payments_db_host" must start with "PAYMENTS_"
+line 4: key "PAYMENTS_DB__PORT" must be UPPER_SNAKE_CASE
+line 5: key "PATH" must start with "PAYMENTS_"
+line 5: key "PATH" is reserved and must not be used
+line 6: key "PAYMENTS_A_VERY_LONG_KEY_NAME" must be at most 24 characters, got 29`, err.Error())
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	var messages []string
+	for _, finding := range findings {
+		messages = append(messages, finding.String())
+	}
+	assert.Equal(t, []string{
+		envFilePath + `:3: key "payments_db_host" must be UPPER_SNAKE_CASE`,
+		envFilePath + `:3: key "payments_db_host" must start with "PAYMENTS_"`,
+		envFilePath + `:4: key "PAYMENTS_DB__PORT" must be UPPER_SNAKE_CASE`,
+		envFilePath + `:5: key "PATH" must start with "PAYMENTS_"`,
+		envFilePath + `:5: key "PATH" is reserved and must not be used`,
+		envFilePath + `:6: key "PAYMENTS_A_VERY_LONG_KEY_NAME" must be at most 24 characters, got 29`,
+	}, messages)
+}
+
+func TestValidateDotEnv_KeyNamingPattern(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "PAYMENTS_DB_HOST=db.internal\nSHARED_REGION=eu\nDB_PORT=5432\n")
+
+	findings, err := newNamingValidator(KeyNamingRules{Pattern: `^(PAYMENTS|SHARED)_`}).Findings(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, 3, findings[0].Line)
+		assert.Equal(t, "DB_PORT", findings[0].Key)
+		assert.Equal(t, `key "DB_PORT" must match "^(PAYMENTS|SHARED)_"`, findings[0].Message)
+	}
+
+	_, err = newNamingValidator(KeyNamingRules{Pattern: `(`}).Findings(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid key naming pattern")
+}
+
+func TestCheckKeyNames_Styles(t *testing.T) {
+	entries := []envEntry{{Key: "DB_HOST", Line: 1}, {Key: "db_host", Line: 2}, {Key: "_DB", Line: 3}, {Key: "DB_", Line: 4}, {Key: "1DB", Line: 5}}
+
+	upper, err := checkKeyNames(entries, KeyNamingRules{Style: KeyStyleUpperSnake})
+	assert.NoError(t, err)
+	var lines []int
+	for _, violation := range upper {
+		lines = append(lines, violation.Line)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5}, lines)
+
+	lower, err := checkKeyNames(entries, KeyNamingRules{Style: KeyStyleLowerSnake})
+	assert.NoError(t, err)
+	lines = nil
+	for _, violation := range lower {
+		lines = append(lines, violation.Line)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5}, lines)
+
+	// Reserved names are matched case-insensitively.
+	reserved, err := checkKeyNames([]envEntry{{Key: "home", Line: 1}}, KeyNamingRules{ReservedNames: []string{"HOME"}})
+	assert.NoError(t, err)
+	assert.Len(t, reserved, 1)
+}
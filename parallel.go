@@ -0,0 +1,140 @@
+package validot
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// pluginOutcome records the result of running one plugin against one key.
+type pluginOutcome struct {
+	plugin  string // The name of the plugin.
+	handled bool   // Whether the plugin handled the key.
+	err     error  // The validation error reported by the plugin, if any.
+	note    string // A description of the value from a plugins.DescriberPlugin, collected in verbose mode.
+}
+
+// keyResult holds the outcome of running the plugins against a single key.
+type keyResult struct {
+	checked  bool            // Whether every plugin ran (or validation stopped at a failing plugin).
+	outcomes []pluginOutcome // The outcome of each plugin that ran, in plugin order.
+	failed   bool            // Whether any plugin reported an error other than a warning.
+}
+
+// checkKey runs the plugins against a single key.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key to validate.
+//   - value: The value to validate.
+//   - stopOnError: If true, the remaining plugins are skipped after the first error.
+//
+// Returns:
+//   - keyResult: The outcome of each plugin that ran; checked is false if the context was done first.
+func (v *Validator) checkKey(ctx context.Context, key, value string, stopOnError bool) keyResult {
+	var result keyResult
+	for _, plugin := range v.plugins {
+		handled, err := v.runPlugin(ctx, plugin, key, value)
+		if ctx.Err() != nil {
+			return result
+		}
+		outcome := pluginOutcome{plugin: plugin.Name(), handled: handled, err: err}
+		if describer, ok := plugin.(plugins.DescriberPlugin); ok && v.config.Verbose && handled && err == nil {
+			outcome.note, _ = describer.Describe(key, value)
+		}
+		result.outcomes = append(result.outcomes, outcome)
+		if err != nil && !plugins.IsWarning(err) {
+			result.failed = true
+			if stopOnError {
+				break
+			}
+		}
+	}
+	result.checked = true
+	return result
+}
+
+// checkKeys runs the plugins against every key, using up to Config.Parallelism workers.
+// Results are returned in the order of keys regardless of how the work was scheduled.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - keys: The keys to validate, in the order results should be reported.
+//   - values: The values to validate, keyed by key.
+//   - stopOnError: If true, keys after the first failing key are not checked.
+//
+// Returns:
+//   - []keyResult: One result per key; keys that were skipped or interrupted are not marked as checked.
+func (v *Validator) checkKeys(ctx context.Context, keys []string, values map[string]string, stopOnError bool) []keyResult {
+	results := make([]keyResult, len(keys))
+
+	workers := min(v.config.Parallelism, len(keys))
+	if workers <= 1 {
+		for i, key := range keys {
+			if ctx.Err() != nil {
+				break
+			}
+			results[i] = v.checkKey(ctx, key, values[key], stopOnError)
+			if stopOnError && results[i].failed {
+				break
+			}
+		}
+		return results
+	}
+
+	// firstFailure is the lowest index of a failing key seen so far; keys after it
+	// are skipped so that the reported error is the same as in sequential mode.
+	var firstFailure atomic.Int64
+	firstFailure.Store(int64(len(keys)))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil || (stopOnError && int64(i) > firstFailure.Load()) {
+					continue
+				}
+				results[i] = v.checkKey(ctx, keys[i], values[keys[i]], stopOnError)
+				if stopOnError && results[i].failed {
+					for {
+						current := firstFailure.Load()
+						if int64(i) >= current || firstFailure.CompareAndSwap(current, int64(i)) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+
+	for i := range keys {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// uncheckedKeys returns the keys whose results are not marked as checked.
+//
+// Parameters:
+//   - keys: The keys that were validated.
+//   - results: The results returned by checkKeys for those keys.
+//
+// Returns:
+//   - []string: The unchecked keys, in order.
+func uncheckedKeys(keys []string, results []keyResult) []string {
+	var unchecked []string
+	for i, key := range keys {
+		if !results[i].checked {
+			unchecked = append(unchecked, key)
+		}
+	}
+	return unchecked
+}
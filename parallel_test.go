@@ -0,0 +1,147 @@
+// parallel_test.go
+package validot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// FileCheckValidationPlugin is a custom plugin for testing purposes.
+// It simulates an expensive plugin by checking the filesystem and waiting briefly
+// for every "PATH_" key, and rejects values containing "missing".
+type FileCheckValidationPlugin struct {
+	Dir string
+}
+
+// Validate checks that the file named by the value exists in Dir.
+func (p *FileCheckValidationPlugin) Validate(key, value string) (bool, error) {
+	if !strings.HasPrefix(key, "PATH_") {
+		return false, nil
+	}
+	time.Sleep(200 * time.Microsecond)
+	if _, err := os.Stat(filepath.Join(p.Dir, value)); err != nil {
+		return true, fmt.Errorf("file for key %q does not exist", key)
+	}
+	return true, nil
+}
+
+// Name returns the name of the plugin.
+func (p *FileCheckValidationPlugin) Name() string {
+	return "FileCheckValidationPlugin"
+}
+
+// createLargeEnvFile writes a `.env` file with the given number of PATH_ keys, all
+// pointing at an existing file except those whose index is listed in missing.
+func createLargeEnvFile(t testing.TB, count int, missing ...int) (string, string) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "present.txt"), nil, 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		value := "present.txt"
+		for _, m := range missing {
+			if m == i {
+				value = "missing.txt"
+			}
+		}
+		fmt.Fprintf(&sb, "PATH_%04d=%s\n", i, value)
+	}
+
+	envFilePath := filepath.Join(dir, ".env")
+	if err := os.WriteFile(envFilePath, []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("Failed to create temp .env file: %v", err)
+	}
+	return envFilePath, dir
+}
+
+func TestValidateDotEnv_ParallelMatchesSequential(t *testing.T) {
+	envFilePath, dir := createLargeEnvFile(t, 200, 150, 40, 90)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	newValidator := func(parallelism int) *Validator {
+		return NewValidator(Config{
+			Logger:      logger,
+			Plugins:     []plugins.ValidationPlugin{&FileCheckValidationPlugin{Dir: dir}},
+			Parallelism: parallelism,
+		}, nil)
+	}
+
+	sequentialFindings, err := newValidator(1).Findings(envFilePath)
+	assert.NoError(t, err)
+	parallelFindings, err := newValidator(8).Findings(envFilePath)
+	assert.NoError(t, err)
+	assert.Len(t, parallelFindings, 3)
+	assert.Equal(t, sequentialFindings, parallelFindings, "Expected findings in the same order")
+
+	// With stopOnError, the first failing key in key order is reported no matter which worker finishes first.
+	entries, err := loadEnvFile(envFilePath)
+	assert.NoError(t, err)
+	values, err := resolveEnvValues(entries, InterpolateExpanded, nil)
+	assert.NoError(t, err)
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+
+	sequential := newValidator(1).checkKeys(context.Background(), keys, values, true)
+	for i := 0; i < 5; i++ {
+		parallel := newValidator(8).checkKeys(context.Background(), keys, values, true)
+		for j := 0; j <= 40; j++ {
+			assert.Equal(t, sequential[j], parallel[j])
+		}
+		assert.True(t, parallel[40].failed)
+	}
+
+	// Keys follow file order, so the error returned by ValidateDotEnv is reproducible too.
+	sequentialErr := newValidator(1).ValidateDotEnv(envFilePath)
+	assert.EqualError(t, sequentialErr, `file for key "PATH_0040" does not exist`)
+	assert.Equal(t, sequentialErr, newValidator(8).ValidateDotEnv(envFilePath))
+}
+
+func benchmarkValidateDotEnv(b *testing.B, parallelism int) {
+	envFilePath, dir := createLargeEnvFile(b, 1000)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator := NewValidator(Config{
+		Logger:      logger,
+		Plugins:     []plugins.ValidationPlugin{&FileCheckValidationPlugin{Dir: dir}},
+		Parallelism: parallelism,
+	}, nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validator.ValidateDotEnv(envFilePath); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateDotEnv_Sequential(b *testing.B) {
+	benchmarkValidateDotEnv(b, 1)
+}
+
+func BenchmarkValidateDotEnv_Parallel4(b *testing.B) {
+	benchmarkValidateDotEnv(b, 4)
+}
+
+func BenchmarkValidateDotEnv_Parallel16(b *testing.B) {
+	benchmarkValidateDotEnv(b, 16)
+}
@@ -0,0 +1,291 @@
+package validot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	charComment       = '#'
+	prefixSingleQuote = '\''
+	prefixDoubleQuote = '"'
+	exportPrefix      = "export"
+)
+
+var (
+	// errUnterminatedQuote is wrapped by the syntaxError for a quoted value without a closing quote.
+	errUnterminatedQuote = errors.New("unterminated quoted value")
+
+	escapeRegex        = regexp.MustCompile(`\\.`)
+	unescapeCharsRegex = regexp.MustCompile(`\\([^$])`)
+)
+
+// envEntry represents a single key-value assignment parsed from a `.env` file.
+// Values are kept exactly as written (after quote removal and escape processing),
+// so that `${KEY}` references can be inspected before they are expanded.
+type envEntry struct {
+	Key    string // The key of the environment variable.
+	Value  string // The raw value, with quotes removed and escapes processed but references not expanded.
+	Quote  byte   // The quote character enclosing the value ('"' or '\''), or 0 if unquoted.
+	Line   int    // The 1-based line number on which the assignment starts.
+	Offset int    // The byte offset of the assignment within the file, including any `export` prefix.
+	Start  int    // The byte offset of the value as written (including quotes) within the file.
+	End    int    // The byte offset just past the value as written within the file.
+}
+
+// syntaxError is a syntax problem found by the parser, with the line it is on.
+type syntaxError struct {
+	Line int   // The 1-based line number of the problem.
+	Err  error // The problem.
+}
+
+// Error formats the problem with its line number.
+//
+// Returns:
+//   - string: The formatted problem.
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying problem.
+//
+// Returns:
+//   - error: The problem.
+func (e *syntaxError) Unwrap() error {
+	return e.Err
+}
+
+// envParser is a position-aware parser for `.env` files. It accepts the same syntax as
+// godotenv (v1.5), which validot used before, but keeps track of line numbers and does not
+// expand references; interpolator expands them afterwards, with different rules than
+// godotenv: references may point to keys defined later in the file, references to keys the
+// file does not define fall back to the process environment instead of becoming empty, and
+// references that cannot be resolved or form a cycle are errors.
+type envParser struct {
+	src  []byte // The file contents being parsed.
+	pos  int    // The current byte offset into src.
+	line int    // The 1-based line number of the current offset.
+}
+
+// parseEnvFile reads and parses the `.env` file at the specified path.
+//
+// Parameters:
+//   - filePath: The path to the `.env` file.
+//
+// Returns:
+//   - []envEntry: The assignments in the order they appear in the file.
+//   - error: An error if reading or parsing the file fails.
+func parseEnvFile(filePath string) ([]envEntry, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return parseEnv(src)
+}
+
+// parseEnv parses the contents of a `.env` file.
+//
+// Parameters:
+//   - src: The contents of the `.env` file.
+//
+// Returns:
+//   - []envEntry: The assignments in the order they appear in the file, up to the first syntax problem.
+//   - error: A *syntaxError describing the first syntax problem found, including its line number.
+func parseEnv(src []byte) ([]envEntry, error) {
+	p := &envParser{src: src, line: 1}
+	var entries []envEntry
+
+	for p.skipToStatement() {
+		entry := envEntry{Line: p.line, Offset: p.pos}
+
+		key, err := p.readKey()
+		if err != nil {
+			return entries, err
+		}
+		entry.Key = key
+
+		entry.Start = p.pos
+		value, quote, err := p.readValue()
+		if err != nil {
+			return entries, err
+		}
+		entry.Value = value
+		entry.Quote = quote
+		entry.End = p.pos
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// advance moves the parser forward by n bytes, keeping the line count up to date.
+func (p *envParser) advance(n int) {
+	for i := 0; i < n && p.pos < len(p.src); i++ {
+		if p.src[p.pos] == '\n' {
+			p.line++
+		}
+		p.pos++
+	}
+}
+
+// skipToStatement skips whitespace, blank lines and comment lines.
+//
+// Returns:
+//   - bool: True if a statement follows, or false if the end of the input was reached.
+func (p *envParser) skipToStatement() bool {
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRune(p.src[p.pos:])
+		switch {
+		case unicode.IsSpace(r):
+			p.advance(size)
+		case r == charComment:
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.advance(1)
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// skipSpaces skips spaces and tabs, but not line breaks.
+func (p *envParser) skipSpaces() {
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRune(p.src[p.pos:])
+		if !isSpace(r) {
+			return
+		}
+		p.advance(size)
+	}
+}
+
+// readKey reads the key of an assignment, including an optional `export` prefix,
+// and consumes the `=` (or `:`) separator.
+//
+// Returns:
+//   - string: The key of the assignment.
+//   - error: An error if the key contains invalid characters or no separator is found.
+func (p *envParser) readKey() (string, error) {
+	rest := p.src[p.pos:]
+	if strings.HasPrefix(string(rest), exportPrefix) {
+		r, _ := utf8.DecodeRune(rest[len(exportPrefix):])
+		if isSpace(r) {
+			p.advance(len(exportPrefix))
+			p.skipSpaces()
+		}
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRune(p.src[p.pos:])
+		switch {
+		case r == '=' || r == ':':
+			key := strings.TrimRightFunc(string(p.src[start:p.pos]), unicode.IsSpace)
+			p.advance(size)
+			p.skipSpaces()
+			return key, nil
+		case isSpace(r), r == '_', r == '.', unicode.IsLetter(r), unicode.IsNumber(r):
+			p.advance(size)
+		default:
+			return "", &syntaxError{Line: p.line, Err: fmt.Errorf("unexpected character %q in variable name near %q", string(r), firstLine(p.src[start:]))}
+		}
+	}
+
+	return "", &syntaxError{Line: p.line, Err: fmt.Errorf("missing '=' after variable name %q", strings.TrimSpace(string(p.src[start:])))}
+}
+
+// readValue reads the value of an assignment, which may be unquoted, single-quoted or double-quoted.
+//
+// Returns:
+//   - string: The value with quotes removed and escapes processed.
+//   - byte: The quote character enclosing the value, or 0 if unquoted.
+//   - error: An error if a quoted value is not terminated.
+func (p *envParser) readValue() (string, byte, error) {
+	if p.pos >= len(p.src) {
+		return "", 0, nil
+	}
+
+	quote := p.src[p.pos]
+	if quote != prefixDoubleQuote && quote != prefixSingleQuote {
+		end := p.pos
+		for end < len(p.src) && p.src[end] != '\n' && p.src[end] != '\r' {
+			end++
+		}
+
+		// Work backwards to strip an inline comment preceded by whitespace.
+		endOfVar := end
+		for i := end - 1; i > p.pos; i-- {
+			if r, _ := utf8.DecodeLastRune(p.src[p.pos:i]); p.src[i] == charComment && isSpace(r) {
+				endOfVar = i
+				break
+			}
+		}
+
+		value := strings.TrimRightFunc(string(p.src[p.pos:endOfVar]), isSpace)
+		p.advance(len(value))
+		return value, 0, nil
+	}
+
+	startLine := p.line
+	for i := p.pos + 1; i < len(p.src); i++ {
+		if p.src[i] != quote || p.src[i-1] == '\\' {
+			continue
+		}
+
+		value := string(p.src[p.pos+1 : i])
+		p.advance(i + 1 - p.pos)
+		if quote == prefixDoubleQuote {
+			value = expandEscapes(value)
+		}
+		return value, quote, nil
+	}
+
+	return "", 0, &syntaxError{Line: startLine, Err: fmt.Errorf("%w %s", errUnterminatedQuote, firstLine(p.src[p.pos:]))}
+}
+
+// expandEscapes processes escape sequences in double-quoted values. Escaped
+// dollar signs are left in place so that interpolation can treat them as literals.
+//
+// Parameters:
+//   - str: The double-quoted value without its quotes.
+//
+// Returns:
+//   - string: The value with escape sequences processed.
+func expandEscapes(str string) string {
+	out := escapeRegex.ReplaceAllStringFunc(str, func(match string) string {
+		switch strings.TrimPrefix(match, `\`) {
+		case "n":
+			return "\n"
+		case "r":
+			return "\r"
+		default:
+			return match
+		}
+	})
+	return unescapeCharsRegex.ReplaceAllString(out, "$1")
+}
+
+// isSpace reports whether the rune is a space character but not a line feed.
+func isSpace(r rune) bool {
+	switch r {
+	case '\t', '\v', '\f', '\r', ' ', 0x85, 0xA0:
+		return true
+	}
+	return false
+}
+
+// firstLine returns the text of src up to, but not including, the first line break.
+func firstLine(src []byte) string {
+	s := string(src)
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+// parser_test.go
+package validot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnv(t *testing.T) {
+	src := []byte(`# comment
+export API_KEY=abc123 # inline comment
+QUOTED="line1\nline2"
+SINGLE='${RAW}'
+MULTI="first
+second"
+
+EMPTY=
+LAST: yaml-style`)
+
+	entries, err := parseEnv(src)
+	assert.NoError(t, err)
+
+	// Offsets point at the value as written, including quotes but not inline comments,
+	// and at the assignment including its `export` prefix.
+	assert.Equal(t, "abc123", string(src[entries[0].Start:entries[0].End]))
+	assert.Equal(t, `"line1\nline2"`, string(src[entries[1].Start:entries[1].End]))
+	assert.Equal(t, "export API_KEY=", string(src[entries[0].Offset:entries[0].Start]))
+	for i := range entries {
+		entries[i].Offset, entries[i].Start, entries[i].End = 0, 0, 0
+	}
+
+	assert.Equal(t, []envEntry{
+		{Key: "API_KEY", Value: "abc123", Line: 2},
+		{Key: "QUOTED", Value: "line1\nline2", Quote: '"', Line: 3},
+		{Key: "SINGLE", Value: "${RAW}", Quote: '\'', Line: 4},
+		{Key: "MULTI", Value: "first\nsecond", Quote: '"', Line: 5},
+		{Key: "EMPTY", Value: "", Line: 8},
+		{Key: "LAST", Value: "yaml-style", Line: 9},
+	}, entries)
+}
+
+func TestParseEnv_Errors(t *testing.T) {
+	entries, err := parseEnv([]byte("A=1\nB=\"unterminated\nC=3\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: unterminated quoted value")
+	assert.ErrorIs(t, err, errUnterminatedQuote)
+	// Entries before the problem are returned.
+	assert.Len(t, entries, 1)
+
+	_, err = parseEnv([]byte("A=1\nBAD-KEY=2\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: unexpected character \"-\" in variable name")
+}
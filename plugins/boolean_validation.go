@@ -42,11 +42,83 @@ func (p *BooleanValidationPlugin) Validate(key, value string) (bool, error) {
 		return true, fmt.Errorf("value for key %q must be a boolean (accepted values: %v)", key, p.AcceptedValues)
 	}
 
-	if p.Standardize {
-		// Example of standardization logic can be implemented here if needed.
+	return true, nil
+}
+
+// Fix rewrites an accepted boolean representation to its canonical form ("true" or "false")
+// when `Standardize` is enabled.
+//
+// Parameters:
+//   - key: The key of the environment variable being fixed.
+//   - value: The current value of the environment variable.
+//
+// Returns:
+//   - string: The canonical value, or the original value if no fix applies.
+//   - bool: True if the value was changed.
+func (p *BooleanValidationPlugin) Fix(key, value string) (string, bool) {
+	if key != p.Key || !p.Standardize {
+		return value, false
+	}
+	if _, err := p.Validate(key, value); err != nil {
+		return value, false
 	}
 
-	return true, nil
+	canonical, ok := canonicalBool(value)
+	if !ok || canonical == value {
+		return value, false
+	}
+	return canonical, true
+}
+
+// Bool returns the boolean meaning of a valid value, e.g. true for "yes".
+//
+// Parameters:
+//   - value: The value to interpret.
+//
+// Returns:
+//   - bool: The boolean value.
+//   - error: An error if the value is not accepted by the plugin or has no common boolean meaning.
+func (p *BooleanValidationPlugin) Bool(value string) (bool, error) {
+	if _, err := p.Validate(p.Key, value); err != nil {
+		return false, err
+	}
+	return ParseBool(value)
+}
+
+// ParseBool interprets a common boolean representation: "true", "t", "1", "yes", "y" and "on"
+// are true, and "false", "f", "0", "no", "n" and "off" are false, regardless of case and
+// surrounding whitespace. This is the interpretation used by BooleanValidationPlugin.
+//
+// Parameters:
+//   - value: The boolean representation to interpret.
+//
+// Returns:
+//   - bool: The boolean value.
+//   - error: An error if the value is not a recognized boolean representation.
+func ParseBool(value string) (bool, error) {
+	canonical, ok := canonicalBool(value)
+	if !ok {
+		return false, fmt.Errorf("%q is not a boolean", value)
+	}
+	return canonical == "true", nil
+}
+
+// canonicalBool maps a common boolean representation to "true" or "false".
+//
+// Parameters:
+//   - value: The boolean representation to map.
+//
+// Returns:
+//   - string: "true" or "false".
+//   - bool: False if the value is not a recognized boolean representation.
+func canonicalBool(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "t", "1", "yes", "y", "on":
+		return "true", true
+	case "false", "f", "0", "no", "n", "off":
+		return "false", true
+	}
+	return "", false
 }
 
 // Name provides the name of the plugin.
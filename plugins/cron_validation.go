@@ -0,0 +1,337 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronValidationPlugin validates that the value of a specific environment variable key,
+// such as `BACKUP_CRON`, is a cron schedule. The standard 5-field form
+// (`minute hour day-of-month month day-of-week`) is always accepted; AllowSeconds also
+// accepts a 6-field form with a leading seconds field. Fields support `*`, lists (`1,15`),
+// ranges (`1-5`), steps (`*/10`), and month and weekday names (`JAN`, `MON`). As in
+// Vixie cron, a schedule that restricts both day fields runs when either one matches.
+// Runs that fall in the hour skipped by a daylight saving change do not happen.
+//
+// In verbose mode the Validator logs when a valid schedule next runs.
+type CronValidationPlugin struct {
+	Key              string         // The key of the environment variable to validate.
+	AllowSeconds     bool           // If true, also accepts the 6-field form with a leading seconds field.
+	RequireSeconds   bool           // If true, only the 6-field form is accepted.
+	AllowDescriptors bool           // If true, accepts "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly" and "@every <duration>".
+	Location         *time.Location // The time zone the schedule runs in, used to compute the next run; defaults to UTC.
+}
+
+// cronSchedule is a parsed cron expression. Each field is a bit set of the values it matches.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	domStar, dowStar                      bool          // Whether the day fields start with "*" or are "?".
+	every                                 time.Duration // The interval of an "@every" schedule; other fields are unused if set.
+}
+
+// cronField describes the allowed values of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week 7 is an alias for Sunday (0).
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors maps the supported "@" shortcuts to their 5-field equivalents.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronHorizon is how far ahead the next run of a schedule is searched for. A schedule
+// with no run in this period, such as February 30th, is considered to never run.
+const cronHorizon = 5 // years
+
+// Validate checks if the value associated with the given key is a valid cron schedule
+// that runs at least once.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *CronValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	schedule, err := p.parse(value)
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be a cron schedule: %v", key, err)
+	}
+	if _, ok := schedule.next(time.Now().In(p.location())); !ok {
+		return true, fmt.Errorf("value for key %q is a cron schedule that never runs", key)
+	}
+
+	return true, nil
+}
+
+// Describe reports when the schedule next runs, for verbose output.
+//
+// Parameters:
+//   - key: The key of the environment variable being described.
+//   - value: The cron schedule.
+//
+// Returns:
+//   - string: The next run time, in RFC 3339 format.
+//   - bool: True if the key is handled by this plugin and the schedule runs.
+func (p *CronValidationPlugin) Describe(key, value string) (string, bool) {
+	if key != p.Key {
+		return "", false // Plugin does not handle this key.
+	}
+	next, err := p.Next(value, time.Now())
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("Next run: %s", next.Format(time.RFC3339)), true
+}
+
+// Next returns the first time after the given time at which the schedule runs, in the
+// plugin's Location.
+//
+// Parameters:
+//   - value: The cron schedule.
+//   - after: The time to search from; the result is strictly later.
+//
+// Returns:
+//   - time.Time: The next run time.
+//   - error: An error if the schedule is invalid or never runs.
+func (p *CronValidationPlugin) Next(value string, after time.Time) (time.Time, error) {
+	schedule, err := p.parse(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next, ok := schedule.next(after.In(p.location()))
+	if !ok {
+		return time.Time{}, fmt.Errorf("schedule never runs")
+	}
+	return next, nil
+}
+
+// location returns the configured time zone, defaulting to UTC.
+func (p *CronValidationPlugin) location() *time.Location {
+	if p.Location != nil {
+		return p.Location
+	}
+	return time.UTC
+}
+
+// parse parses a cron expression or, if allowed, a descriptor.
+//
+// Parameters:
+//   - value: The cron schedule.
+//
+// Returns:
+//   - *cronSchedule: The parsed schedule.
+//   - error: An error describing the first problem found.
+func (p *CronValidationPlugin) parse(value string) (*cronSchedule, error) {
+	value = strings.TrimSpace(value)
+	descriptor := strings.HasPrefix(value, "@")
+	if descriptor {
+		if !p.AllowDescriptors {
+			return nil, fmt.Errorf("descriptors such as %q are not allowed", value)
+		}
+		if interval, ok := strings.CutPrefix(value, "@every "); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(interval))
+			if err != nil || d < time.Second {
+				return nil, fmt.Errorf("@every requires a duration of at least 1s, got %q", interval)
+			}
+			return &cronSchedule{every: d}, nil
+		}
+		expanded, ok := cronDescriptors[strings.ToLower(value)]
+		if !ok {
+			return nil, fmt.Errorf("unknown descriptor %q", value)
+		}
+		value = expanded
+	}
+
+	fields := strings.Fields(value)
+	switch {
+	case len(fields) == 5 && (descriptor || !p.RequireSeconds):
+		fields = append([]string{"0"}, fields...)
+	case len(fields) == 6 && (p.AllowSeconds || p.RequireSeconds):
+	case p.RequireSeconds:
+		return nil, fmt.Errorf("expected 6 fields, got %d", len(fields))
+	case p.AllowSeconds:
+		return nil, fmt.Errorf("expected 5 or 6 fields, got %d", len(fields))
+	default:
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var schedule cronSchedule
+	var err error
+	specs := []cronField{cronSecond, cronMinute, cronHour, cronDom, cronMonth, cronDow}
+	sets := []*uint64{&schedule.second, &schedule.minute, &schedule.hour, &schedule.dom, &schedule.month, &schedule.dow}
+	for i, field := range fields {
+		if *sets[i], err = parseCronField(field, specs[i]); err != nil {
+			return nil, err
+		}
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	// As in Vixie cron, a day field starting with "*" (including "*/2") is unrestricted.
+	schedule.domStar = strings.HasPrefix(fields[3], "*") || fields[3] == "?"
+	schedule.dowStar = strings.HasPrefix(fields[5], "*") || fields[5] == "?"
+
+	return &schedule, nil
+}
+
+// parseCronField parses one field of a cron expression into a bit set.
+//
+// Parameters:
+//   - expr: The field, e.g. "*/15", "1-5" or "MON,WED,FRI".
+//   - spec: The allowed values of the field.
+//
+// Returns:
+//   - uint64: A bit set with bit n set if the field matches value n.
+//   - error: An error if the field is malformed or out of range.
+func parseCronField(expr string, spec cronField) (uint64, error) {
+	if expr == "?" && (spec.name == cronDom.name || spec.name == cronDow.name) {
+		expr = "*"
+	}
+
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 || n > spec.max {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, spec.name)
+			}
+			step = n
+		}
+
+		lo, hi := spec.min, spec.max
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = parseCronValue(first, spec); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if hi, err = parseCronValue(last, spec); err != nil {
+					return 0, err
+				}
+				if lo > hi {
+					return 0, fmt.Errorf("range %q in %s field ends before it starts", rangePart, spec.name)
+				}
+			case !hasStep:
+				hi = lo // A single value; "5/15" means from 5 to the maximum in steps of 15.
+			}
+		}
+
+		for n := lo; n <= hi; n += step {
+			set |= 1 << n
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a single number or name in a cron field.
+//
+// Parameters:
+//   - s: The value.
+//   - spec: The allowed values of the field.
+//
+// Returns:
+//   - int: The numeric value.
+//   - error: An error if the value is not a number or name within the field's range.
+func parseCronValue(s string, spec cronField) (int, error) {
+	if n, ok := spec.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || strings.ContainsAny(s, "+-") {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, spec.name)
+	}
+	if n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("value %d in %s field must be between %d and %d", n, spec.name, spec.min, spec.max)
+	}
+	return n, nil
+}
+
+// next returns the first time strictly after t that matches the schedule, searching up to
+// cronHorizon years ahead. Times are computed in t's location.
+//
+// Parameters:
+//   - t: The time to search from.
+//
+// Returns:
+//   - time.Time: The next matching time.
+//   - bool: False if the schedule does not run within the horizon.
+func (s *cronSchedule) next(t time.Time) (time.Time, bool) {
+	if s.every > 0 {
+		return t.Add(s.every).Truncate(time.Second), true
+	}
+
+	loc := t.Location()
+	t = t.Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronHorizon
+	for t.Year() <= limit {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		case s.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dayMatches reports whether the day of t matches the day-of-month and day-of-week fields.
+// If both fields are restricted, either may match.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *CronValidationPlugin) Name() string {
+	return "CronValidationPlugin"
+}
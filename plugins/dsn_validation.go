@@ -0,0 +1,449 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DSNValidationPlugin validates that the value of a specific environment variable key
+// is a well-formed database or cache connection string. It understands PostgreSQL
+// (`postgres://` URLs and `key=value` keyword strings), MySQL (driver DSN syntax such as
+// `user:pass@tcp(host:3306)/db` and `mysql://` URLs), Redis (`redis://` and `rediss://`)
+// and MongoDB (`mongodb://` and `mongodb+srv://`). Parsing is done offline; no connection is made.
+type DSNValidationPlugin struct {
+	Key             string            // The key of the environment variable to validate.
+	AllowedSchemes  []string          // Allowed kinds: "postgres", "mysql", "redis", "rediss", "mongodb", "mongodb+srv". Optional; all are allowed by default.
+	RequireDatabase bool              // If true, the connection string must name a database.
+	RequireTLS      bool              // If true, TLS must be enforced (e.g. `sslmode=require`, `tls=true` or `rediss://`).
+	ForbidPassword  bool              // If true, rejects passwords embedded in the connection string (e.g. when a separate secret key is expected).
+	RequiredParams  map[string]string // Query or keyword parameters that must be present with the given value; an empty value only requires presence. Optional.
+}
+
+// dsn holds the parts of a parsed connection string that are validated.
+type dsn struct {
+	scheme      string            // The kind of connection string, e.g. "postgres" or "mongodb+srv".
+	hosts       []string          // The hosts, each optionally followed by `:port`.
+	hasPassword bool              // Whether a password is embedded.
+	database    string            // The database name or number, if any.
+	params      map[string]string // The query or keyword parameters.
+	tls         bool              // Whether TLS is enforced.
+}
+
+var (
+	// mysqlDSNRegex matches the go-sql-driver/mysql DSN syntax:
+	// [user[:password]@][protocol[(address)]]/dbname[?param1=value1&paramN=valueN]
+	mysqlDSNRegex = regexp.MustCompile(`^(?:([^:@/]*)(?::([^@]*))?@)?(?:(\w+)(?:\(([^)]*)\))?)?/([^?]*)(?:\?(.*))?$`)
+
+	// postgresKeywordRegex matches the start of a PostgreSQL keyword connection string, e.g. `host=`.
+	postgresKeywordRegex = regexp.MustCompile(`^\w+\s*=`)
+
+	// postgresSSLModes lists the valid values of the PostgreSQL `sslmode` parameter.
+	postgresSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+)
+
+// Validate checks if the value associated with the given key is a valid connection
+// string and meets the configured TLS, database, password and parameter requirements.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *DSNValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	d, err := parseDSN(strings.TrimSpace(value))
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be a valid connection string: %v", key, err)
+	}
+
+	if len(p.AllowedSchemes) > 0 {
+		allowed := false
+		for _, scheme := range p.AllowedSchemes {
+			if strings.EqualFold(scheme, d.scheme) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return true, fmt.Errorf("connection string for key %q must be one of %v, got %q", key, p.AllowedSchemes, d.scheme)
+		}
+	}
+
+	for _, host := range d.hosts {
+		if err := validateDSNHost(host); err != nil {
+			return true, fmt.Errorf("connection string for key %q has an invalid host %q: %v", key, host, err)
+		}
+	}
+
+	if p.RequireDatabase && d.database == "" {
+		return true, fmt.Errorf("connection string for key %q must specify a database", key)
+	}
+	if p.ForbidPassword && d.hasPassword {
+		return true, fmt.Errorf("connection string for key %q must not contain an embedded password", key)
+	}
+	if p.RequireTLS && !d.tls {
+		return true, fmt.Errorf("connection string for key %q must enforce TLS", key)
+	}
+
+	for param, want := range p.RequiredParams {
+		got, ok := d.params[param]
+		if !ok {
+			return true, fmt.Errorf("connection string for key %q must set parameter %q", key, param)
+		}
+		if want != "" && got != want {
+			return true, fmt.Errorf("connection string for key %q must set parameter %q to %q", key, param, want)
+		}
+	}
+
+	return true, nil
+}
+
+// parseDSN parses a connection string of any supported kind.
+//
+// Parameters:
+//   - value: The connection string.
+//
+// Returns:
+//   - *dsn: The parsed connection string.
+//   - error: An error if the connection string is malformed or of an unsupported kind.
+func parseDSN(value string) (*dsn, error) {
+	if value == "" {
+		return nil, fmt.Errorf("connection string is empty")
+	}
+
+	scheme, _, isURL := strings.Cut(value, "://")
+	if !isURL {
+		if postgresKeywordRegex.MatchString(value) {
+			return parsePostgresKeywords(value)
+		}
+		return parseMySQLDSN(value)
+	}
+
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+		return parsePostgresURL(value)
+	case "mysql":
+		return parseMySQLURL(value)
+	case "redis", "rediss":
+		return parseRedisURL(value)
+	case "mongodb", "mongodb+srv":
+		return parseMongoURL(value)
+	}
+	return nil, fmt.Errorf("unsupported scheme %q", scheme)
+}
+
+// parsePostgresURL parses a `postgres://` or `postgresql://` URL.
+func parsePostgresURL(value string) (*dsn, error) {
+	u, err := parseMultiHostURL(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// With no host, libpq falls back to a local socket.
+	d := &dsn{scheme: "postgres", hosts: u.hosts, hasPassword: u.hasPassword, database: u.path, params: u.params}
+	if _, ok := d.params["password"]; ok {
+		d.hasPassword = true // libpq also accepts the password as a query parameter.
+	}
+	return d, validatePostgresParams(d)
+}
+
+// parsePostgresKeywords parses a PostgreSQL `key=value` keyword connection string.
+func parsePostgresKeywords(value string) (*dsn, error) {
+	params, err := splitPostgresKeywords(value)
+	if err != nil {
+		return nil, err
+	}
+	d := &dsn{scheme: "postgres", params: params}
+
+	if host := d.params["host"]; host != "" && !strings.HasPrefix(host, "/") {
+		for _, h := range strings.Split(host, ",") {
+			if port := d.params["port"]; port != "" {
+				h = net.JoinHostPort(h, port)
+			}
+			d.hosts = append(d.hosts, h)
+		}
+	}
+	_, d.hasPassword = d.params["password"]
+	d.database = d.params["dbname"]
+	return d, validatePostgresParams(d)
+}
+
+// splitPostgresKeywords splits a PostgreSQL keyword connection string into its parameters
+// following libpq's rules: parameters are separated by whitespace, `=` may be surrounded by
+// whitespace, and values may be single-quoted, with `\'` and `\\` as escapes.
+//
+// Parameters:
+//   - value: The keyword connection string.
+//
+// Returns:
+//   - map[string]string: The parameters, with quotes removed and escapes processed.
+//   - error: An error if a parameter has no `=` or a quoted value is not terminated.
+func splitPostgresKeywords(value string) (map[string]string, error) {
+	params := make(map[string]string)
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+	skipSpaces := func(i int) int {
+		for i < len(value) && isSpace(value[i]) {
+			i++
+		}
+		return i
+	}
+
+	i := skipSpaces(0)
+	for i < len(value) {
+		start := i
+		for i < len(value) && value[i] != '=' && !isSpace(value[i]) {
+			i++
+		}
+		keyword := value[start:i]
+		i = skipSpaces(i)
+		if keyword == "" || i >= len(value) || value[i] != '=' {
+			return nil, fmt.Errorf("invalid keyword parameter %q", strings.Fields(value[start:])[0])
+		}
+		i = skipSpaces(i + 1)
+
+		var v strings.Builder
+		quoted := i < len(value) && value[i] == '\''
+		if quoted {
+			i++
+		}
+		for ; i < len(value); i++ {
+			c := value[i]
+			if c == '\\' && i+1 < len(value) {
+				i++
+				v.WriteByte(value[i])
+				continue
+			}
+			if (quoted && c == '\'') || (!quoted && isSpace(c)) {
+				break
+			}
+			v.WriteByte(c)
+		}
+		if quoted {
+			if i >= len(value) {
+				return nil, fmt.Errorf("unterminated quoted value for keyword parameter %q", keyword)
+			}
+			i++ // Skip the closing quote.
+		}
+
+		params[keyword] = v.String()
+		i = skipSpaces(i)
+	}
+	return params, nil
+}
+
+// validatePostgresParams checks the `sslmode` parameter and records whether it enforces TLS.
+func validatePostgresParams(d *dsn) error {
+	mode, ok := d.params["sslmode"]
+	if !ok {
+		return nil
+	}
+	for _, valid := range postgresSSLModes {
+		if mode == valid {
+			d.tls = mode == "require" || mode == "verify-ca" || mode == "verify-full"
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid sslmode %q (valid values: %v)", mode, postgresSSLModes)
+}
+
+// parseMySQLDSN parses a go-sql-driver/mysql DSN.
+func parseMySQLDSN(value string) (*dsn, error) {
+	m := mysqlDSNRegex.FindStringSubmatch(value)
+	if m == nil {
+		return nil, fmt.Errorf("unrecognized connection string format")
+	}
+
+	d := &dsn{scheme: "mysql", hasPassword: m[2] != "", database: m[5], params: map[string]string{}}
+	switch protocol, address := m[3], m[4]; protocol {
+	case "", "tcp":
+		if address != "" {
+			d.hosts = []string{address}
+		}
+	case "unix":
+		if address == "" {
+			return nil, fmt.Errorf("unix protocol requires a socket path")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", protocol)
+	}
+
+	if m[6] != "" {
+		query, err := url.ParseQuery(m[6])
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters: %v", err)
+		}
+		d.params = flattenQuery(query)
+	}
+	setMySQLTLS(d)
+	return d, nil
+}
+
+// parseMySQLURL parses a `mysql://` URL.
+func parseMySQLURL(value string) (*dsn, error) {
+	u, err := parseMultiHostURL(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(u.hosts) != 1 {
+		return nil, fmt.Errorf("exactly one host is required")
+	}
+
+	d := &dsn{scheme: "mysql", hosts: u.hosts, hasPassword: u.hasPassword, database: u.path, params: u.params}
+	setMySQLTLS(d)
+	return d, nil
+}
+
+// setMySQLTLS records whether the MySQL `tls` parameter enforces TLS.
+func setMySQLTLS(d *dsn) {
+	if tls, ok := d.params["tls"]; ok {
+		d.tls = tls != "false" && tls != "preferred"
+	}
+}
+
+// parseRedisURL parses a `redis://` or `rediss://` URL.
+func parseRedisURL(value string) (*dsn, error) {
+	u, err := parseMultiHostURL(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(u.hosts) != 1 {
+		return nil, fmt.Errorf("exactly one host is required")
+	}
+
+	d := &dsn{scheme: strings.ToLower(u.scheme), hosts: u.hosts, hasPassword: u.hasPassword, database: u.path, params: u.params}
+	if d.database != "" {
+		if n, err := strconv.Atoi(d.database); err != nil || n < 0 {
+			return nil, fmt.Errorf("database %q must be a non-negative number", d.database)
+		}
+	}
+	d.tls = d.scheme == "rediss"
+	return d, nil
+}
+
+// parseMongoURL parses a `mongodb://` or `mongodb+srv://` URL.
+func parseMongoURL(value string) (*dsn, error) {
+	u, err := parseMultiHostURL(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(u.hosts) == 0 {
+		return nil, fmt.Errorf("at least one host is required")
+	}
+
+	d := &dsn{scheme: strings.ToLower(u.scheme), hosts: u.hosts, hasPassword: u.hasPassword, database: u.path, params: u.params}
+	if d.scheme == "mongodb+srv" {
+		if len(d.hosts) != 1 || strings.Contains(d.hosts[0], ":") {
+			return nil, fmt.Errorf("mongodb+srv requires exactly one host without a port")
+		}
+		d.tls = true
+	}
+
+	for _, param := range []string{"tls", "ssl"} {
+		if v, ok := d.params[param]; ok {
+			if v != "true" && v != "false" {
+				return nil, fmt.Errorf("parameter %q must be true or false", param)
+			}
+			d.tls = v == "true"
+		}
+	}
+	return d, nil
+}
+
+// multiHostURL holds the parts of a URL that may list several comma-separated hosts.
+type multiHostURL struct {
+	scheme      string            // The URL scheme.
+	hosts       []string          // The hosts, each optionally followed by `:port`.
+	hasPassword bool              // Whether the userinfo contains a password.
+	path        string            // The path without its leading slash.
+	params      map[string]string // The query parameters.
+}
+
+// parseMultiHostURL parses a URL whose host part may contain a comma-separated list
+// of hosts, which net/url does not support.
+//
+// Parameters:
+//   - value: The URL to parse.
+//
+// Returns:
+//   - *multiHostURL: The parsed URL.
+//   - error: An error if the URL is malformed.
+func parseMultiHostURL(value string) (*multiHostURL, error) {
+	scheme, rest, _ := strings.Cut(value, "://")
+
+	authority := rest
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		authority = rest[:i]
+	}
+	userinfo, hostList := "", authority
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		userinfo, hostList = authority[:i+1], authority[i+1:]
+	}
+
+	// Parse with a placeholder host so net/url validates everything else.
+	u, err := url.Parse(scheme + "://" + userinfo + "placeholder" + rest[len(authority):])
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &multiHostURL{
+		scheme: scheme,
+		path:   strings.TrimPrefix(u.Path, "/"),
+		params: flattenQuery(u.Query()),
+	}
+	if u.User != nil {
+		_, parsed.hasPassword = u.User.Password()
+	}
+	if hostList != "" {
+		parsed.hosts = strings.Split(hostList, ",")
+	}
+	return parsed, nil
+}
+
+// flattenQuery converts parsed query parameters to a map holding the first value of each parameter.
+func flattenQuery(query url.Values) map[string]string {
+	params := make(map[string]string, len(query))
+	for k, v := range query {
+		params[k] = v[0]
+	}
+	return params
+}
+
+// validateDSNHost checks that a host is a valid hostname or IP literal with an optional valid port.
+//
+// Parameters:
+//   - host: The host, optionally followed by `:port`.
+//
+// Returns:
+//   - error: An error if the host or port is invalid.
+func validateDSNHost(host string) error {
+	name, port, hasPort, err := splitHostPort(host)
+	if err != nil {
+		return err
+	}
+	if hasPort {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("port must be between 1 and 65535")
+		}
+	}
+	if _, err := ParseIP(name); err != nil && !isValidHostname(name) {
+		return fmt.Errorf("not a valid hostname or IP address")
+	}
+	return nil
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *DSNValidationPlugin) Name() string {
+	return "DSNValidationPlugin"
+}
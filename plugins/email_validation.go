@@ -0,0 +1,59 @@
+package plugins
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// EmailValidationPlugin validates that the value of a specific environment variable key,
+// such as `ALERT_EMAIL`, is an RFC 5322 email address. By default only a bare address
+// (`ops@example.com`) is accepted; AllowDisplayName also accepts `Ops <ops@example.com>`.
+// The domain must be a fully qualified hostname and can be restricted to allowed domains.
+type EmailValidationPlugin struct {
+	Key              string   // The key of the environment variable to validate.
+	AllowDisplayName bool     // If true, accepts an address with a display name, e.g. "Ops Team <ops@example.com>".
+	AllowedDomains   []string // If set, the address's domain must equal or be a subdomain of one of these domains. Optional.
+}
+
+// Validate checks if the value associated with the given key is a valid email address.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *EmailValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	address, err := mail.ParseAddress(value)
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be a valid email address: %v", key, err)
+	}
+	if !p.AllowDisplayName && (address.Name != "" || strings.HasSuffix(strings.TrimSpace(value), ">")) {
+		return true, fmt.Errorf("value for key %q must be a bare email address without a display name", key)
+	}
+
+	at := strings.LastIndex(address.Address, "@")
+	domain := strings.ToLower(address.Address[at+1:])
+	if !isValidHostname(domain) || !strings.Contains(strings.TrimSuffix(domain, "."), ".") {
+		return true, fmt.Errorf("value for key %q must have a fully qualified domain, got %q", key, domain)
+	}
+	if len(p.AllowedDomains) > 0 && !matchesDomain(strings.TrimSuffix(domain, "."), p.AllowedDomains) {
+		return true, fmt.Errorf("email address for key %q must be within one of the domains %v", key, p.AllowedDomains)
+	}
+
+	return true, nil
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *EmailValidationPlugin) Name() string {
+	return "EmailValidationPlugin"
+}
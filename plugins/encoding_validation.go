@@ -0,0 +1,95 @@
+package plugins
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// EncodingValidationPlugin validates that the value of a specific environment variable key
+// is base64 (standard or URL-safe) or hex encoded, and optionally that it decodes to an
+// expected number of bytes (e.g. a 32-byte AES-256 key).
+type EncodingValidationPlugin struct {
+	Key              string // The key of the environment variable to validate.
+	Encoding         string // The required encoding: "base64", "base64url" or "hex".
+	RequirePadding   bool   // If true, base64 values must include "=" padding; by default padding is optional.
+	DecodedLength    int    // The exact length in bytes of the decoded value; 0 means any length.
+	MinDecodedLength int    // The minimum length in bytes of the decoded value; 0 means no minimum.
+	MaxDecodedLength int    // The maximum length in bytes of the decoded value; 0 means no maximum.
+}
+
+// Validate checks if the value associated with the given key is validly encoded and
+// decodes to an allowed number of bytes.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *EncodingValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	if p.RequirePadding && strings.HasPrefix(strings.ToLower(p.Encoding), "base64") && len(value)%4 != 0 {
+		return true, fmt.Errorf("value for key %q must be padded %s", key, p.Encoding)
+	}
+
+	decoded, err := decodeValue(p.Encoding, value)
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be %s-encoded: %v", key, p.Encoding, err)
+	}
+
+	n := len(decoded)
+	if p.DecodedLength > 0 && n != p.DecodedLength {
+		return true, fmt.Errorf("value for key %q must decode to %d bytes, got %d", key, p.DecodedLength, n)
+	}
+	if p.MinDecodedLength > 0 && n < p.MinDecodedLength {
+		return true, fmt.Errorf("value for key %q must decode to at least %d bytes, got %d", key, p.MinDecodedLength, n)
+	}
+	if p.MaxDecodedLength > 0 && n > p.MaxDecodedLength {
+		return true, fmt.Errorf("value for key %q must decode to at most %d bytes, got %d", key, p.MaxDecodedLength, n)
+	}
+
+	return true, nil
+}
+
+// decodeValue decodes a base64, base64url or hex value. Base64 padding is optional, but
+// if a value has padding it must be correct: exactly as much as its length requires, and
+// only at the end.
+//
+// Parameters:
+//   - encoding: "base64", "base64url" or "hex".
+//   - value: The encoded value.
+//
+// Returns:
+//   - []byte: The decoded bytes.
+//   - error: An error if the value is not valid in the encoding or the encoding is unknown.
+func decodeValue(encoding, value string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "base64":
+		if strings.Contains(value, "=") {
+			return base64.StdEncoding.DecodeString(value)
+		}
+		return base64.RawStdEncoding.DecodeString(value)
+	case "base64url":
+		if strings.Contains(value, "=") {
+			return base64.URLEncoding.DecodeString(value)
+		}
+		return base64.RawURLEncoding.DecodeString(value)
+	case "hex":
+		return hex.DecodeString(value)
+	}
+	return nil, fmt.Errorf("unknown encoding %q", encoding)
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *EncodingValidationPlugin) Name() string {
+	return "EncodingValidationPlugin"
+}
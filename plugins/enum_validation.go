@@ -1,21 +1,41 @@
 package plugins
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
+	"sync"
+
+	"github.com/mwiater/go-validot/internal/suggest"
 )
 
 // EnumValidationPlugin validates that the value of a specific environment variable
 // key is within a predefined set of allowed options. The validation can be
-// configured to be case-sensitive or case-insensitive.
+// configured to be case-sensitive or case-insensitive. Aliases map alternative
+// spellings onto allowed values, and multi-valued enums accept a separated set of
+// distinct values (e.g. `LOG_FORMAT=json,text`). Invalid values are reported with a
+// "did you mean" suggestion when an allowed value is close. AllowedValuesFile and
+// Aliases are loaded and checked on first use, so they must not be changed afterwards.
 type EnumValidationPlugin struct {
-	Key           string   // The key of the environment variable to validate.
-	AllowedValues []string // A list of permissible values for the key.
-	CaseSensitive bool     // If true, validation is case-sensitive; otherwise, it is case-insensitive.
+	Key               string            // The key of the environment variable to validate.
+	AllowedValues     []string          // A list of permissible values for the key.
+	AllowedValuesFile string            // A file listing additional permissible values, one per line; blank lines and `#` comments are ignored. Optional.
+	CaseSensitive     bool              // If true, validation is case-sensitive; otherwise, it is case-insensitive.
+	Aliases           map[string]string // Alternative spellings mapped to allowed values, e.g., "prod" to "PRODUCTION". Optional.
+	Canonicalize      bool              // If true, fix mode rewrites accepted values (including aliases) to their canonical allowed value.
+	MultiValued       bool              // If true, the value is a set of distinct allowed values joined by Separator.
+	Separator         string            // The separator between values of a multi-valued enum; defaults to ",".
+
+	loadOnce sync.Once // Guards the loading of the allowed values and aliases.
+	values   []string  // AllowedValues followed by the values in AllowedValuesFile.
+	aliases  []string  // The keys of Aliases, in sorted order.
+	loadErr  error     // The error from loading AllowedValuesFile or checking Aliases, if any.
 }
 
 // Validate verifies if the value for the specified key is within the allowed set of values.
-// It checks against the plugin's `Key` and `AllowedValues`.
+// It checks against the plugin's `Key`, `AllowedValues` and `Aliases`.
 //
 // Parameters:
 //   - key: The key of the environment variable being validated.
@@ -29,19 +49,211 @@ func (p *EnumValidationPlugin) Validate(key, value string) (bool, error) {
 		return false, nil // Plugin does not handle this key.
 	}
 
-	for _, allowed := range p.AllowedValues {
+	_, err := p.canonical(key, value, !p.CaseSensitive)
+	return true, err
+}
+
+// Canonical returns the canonical form of a valid value: aliases and (for case-insensitive
+// enums) differently cased values are replaced by the allowed value they match, and the
+// values of a multi-valued enum are trimmed and joined by the separator.
+//
+// Parameters:
+//   - value: The value to canonicalize.
+//
+// Returns:
+//   - string: The canonical value.
+//   - error: An error if the value is not valid.
+func (p *EnumValidationPlugin) Canonical(value string) (string, error) {
+	return p.canonical(p.Key, value, !p.CaseSensitive)
+}
+
+// canonical validates value and returns its canonical form.
+//
+// Parameters:
+//   - key: The key of the environment variable, used in error messages.
+//   - value: The value to validate.
+//   - fold: If true, values and aliases match regardless of letter case.
+//
+// Returns:
+//   - string: The canonical value.
+//   - error: An error if the value is not valid.
+func (p *EnumValidationPlugin) canonical(key, value string, fold bool) (string, error) {
+	allowedValues, err := p.load()
+	if err != nil {
+		return "", err
+	}
+
+	if !p.MultiValued {
+		resolved, ok := p.resolve(value, allowedValues, fold)
+		if !ok {
+			return "", p.invalidValue(key, value, allowedValues)
+		}
+		return resolved, nil
+	}
+
+	separator := p.separator()
+	parts := strings.Split(value, separator)
+	seen := make(map[string]bool, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return "", fmt.Errorf("value for key %q must not contain empty values", key)
+		}
+		resolved, ok := p.resolve(part, allowedValues, fold)
+		if !ok {
+			return "", p.invalidValue(key, part, allowedValues)
+		}
+		if seen[resolved] {
+			return "", fmt.Errorf("value for key %q must not contain duplicate value %q", key, resolved)
+		}
+		seen[resolved] = true
+		parts[i] = resolved
+	}
+	return strings.Join(parts, separator), nil
+}
+
+// resolve matches a single value against the allowed values and aliases.
+//
+// Parameters:
+//   - value: The value to match.
+//   - allowedValues: The permissible values.
+//   - fold: If true, matching ignores letter case.
+//
+// Returns:
+//   - string: The allowed value that matched.
+//   - bool: True if the value matched an allowed value or alias.
+func (p *EnumValidationPlugin) resolve(value string, allowedValues []string, fold bool) (string, bool) {
+	equal := func(a, b string) bool {
+		if fold {
+			return strings.EqualFold(a, b)
+		}
+		return a == b
+	}
+
+	// Prefer an exact match so that values differing only in case stay distinct.
+	for _, allowed := range allowedValues {
+		if value == allowed {
+			return allowed, true
+		}
+	}
+	for _, allowed := range allowedValues {
+		if equal(value, allowed) {
+			return allowed, true
+		}
+	}
+	for _, alias := range p.aliases {
+		if value == alias {
+			return p.Aliases[alias], true
+		}
+	}
+	for _, alias := range p.aliases {
+		if equal(value, alias) {
+			return p.Aliases[alias], true
+		}
+	}
+	return "", false
+}
+
+// invalidValue returns the error for a value that matches no allowed value, with a
+// suggestion for the closest allowed value or alias if there is one.
+//
+// Parameters:
+//   - key: The key of the environment variable.
+//   - value: The invalid value.
+//   - allowedValues: The permissible values.
+//
+// Returns:
+//   - error: The validation error.
+func (p *EnumValidationPlugin) invalidValue(key, value string, allowedValues []string) error {
+	candidates := append(append([]string(nil), allowedValues...), p.aliases...)
+
+	if suggestion, ok := suggest.Closest(value, candidates); ok {
+		if target, isAlias := p.Aliases[suggestion]; isAlias {
+			suggestion = target
+		}
+		return fmt.Errorf("value for key %q must be one of %v, got %q (did you mean %q?)", key, allowedValues, value, suggestion)
+	}
+	return fmt.Errorf("value for key %q must be one of %v, got %q", key, allowedValues, value)
+}
+
+// load returns AllowedValues followed by the values listed in AllowedValuesFile, reading
+// the file and checking Aliases on first use.
+//
+// Returns:
+//   - []string: The permissible values.
+//   - error: An error if the file cannot be read or Aliases are invalid.
+func (p *EnumValidationPlugin) load() ([]string, error) {
+	p.loadOnce.Do(func() {
+		if p.values, p.loadErr = p.readAllowedValues(); p.loadErr != nil {
+			p.loadErr = fmt.Errorf("failed to load allowed values for key %q: %v", p.Key, p.loadErr)
+			return
+		}
+		for alias := range p.Aliases {
+			p.aliases = append(p.aliases, alias)
+		}
+		sort.Strings(p.aliases)
+		p.loadErr = p.checkAliases()
+	})
+	return p.values, p.loadErr
+}
+
+// checkAliases checks that every alias maps to an allowed value and, for case-insensitive
+// enums, that no two aliases differing only in case map to different values.
+//
+// Returns:
+//   - error: An error describing the first invalid alias.
+func (p *EnumValidationPlugin) checkAliases() error {
+	for i, alias := range p.aliases {
+		target := p.Aliases[alias]
+		if !contains(p.values, target) {
+			return fmt.Errorf("invalid alias %q for key %q: %q is not an allowed value", alias, p.Key, target)
+		}
 		if p.CaseSensitive {
-			if value == allowed {
-				return true, nil
-			}
-		} else {
-			if strings.EqualFold(value, allowed) {
-				return true, nil
+			continue
+		}
+		for _, other := range p.aliases[:i] {
+			if strings.EqualFold(alias, other) && p.Aliases[other] != target {
+				return fmt.Errorf("invalid alias %q for key %q: it differs only in case from alias %q, which maps to a different value", alias, p.Key, other)
 			}
 		}
 	}
+	return nil
+}
 
-	return true, fmt.Errorf("value for key %q must be one of %v", key, p.AllowedValues)
+// readAllowedValues returns AllowedValues followed by the values listed in AllowedValuesFile.
+//
+// Returns:
+//   - []string: The permissible values.
+//   - error: An error if the file cannot be read.
+func (p *EnumValidationPlugin) readAllowedValues() ([]string, error) {
+	if p.AllowedValuesFile == "" {
+		return p.AllowedValues, nil
+	}
+
+	file, err := os.Open(p.AllowedValuesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := append([]string(nil), p.AllowedValues...)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values = append(values, line)
+	}
+	return values, scanner.Err()
+}
+
+// separator returns the configured separator for multi-valued enums.
+func (p *EnumValidationPlugin) separator() string {
+	if p.Separator == "" {
+		return ","
+	}
+	return p.Separator
 }
 
 // Name provides the name of the plugin.
@@ -51,3 +263,34 @@ func (p *EnumValidationPlugin) Validate(key, value string) (bool, error) {
 func (p *EnumValidationPlugin) Name() string {
 	return "EnumValidationPlugin"
 }
+
+// Fix rewrites a value that matches an allowed value in all but letter case to the
+// allowed value's exact casing. It applies when `CaseSensitive` or `Canonicalize` is
+// true; with `Canonicalize`, aliases are also replaced and multi-valued enums are
+// normalized.
+//
+// Parameters:
+//   - key: The key of the environment variable being fixed.
+//   - value: The current value of the environment variable.
+//
+// Returns:
+//   - string: The fixed value, or the original value if no fix applies.
+//   - bool: True if the value was changed.
+func (p *EnumValidationPlugin) Fix(key, value string) (string, bool) {
+	if key != p.Key || !(p.CaseSensitive || p.Canonicalize) {
+		return value, false
+	}
+
+	if !p.Canonicalize {
+		// Only correct casing; leave aliases and valid values untouched.
+		if _, err := p.canonical(key, value, false); err == nil {
+			return value, false
+		}
+	}
+
+	fixed, err := p.canonical(key, value, true)
+	if err != nil || fixed == value {
+		return value, false
+	}
+	return fixed, true
+}
@@ -0,0 +1,195 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// HostValidationPlugin validates that the value of a specific environment variable
+// key is a host: an RFC 1123 hostname, a fully qualified domain name, or an IPv4/IPv6
+// literal. It can optionally accept or require a `host:port` form (with IPv6 literals
+// in brackets, e.g. `[::1]:6379`), restrict hosts to allowed domains, and forbid localhost.
+type HostValidationPlugin struct {
+	Key               string   // The key of the environment variable to validate.
+	AllowPort         bool     // If true, accepts an optional `:port` suffix.
+	RequirePort       bool     // If true, requires a `:port` suffix.
+	RequireFQDN       bool     // If true, hostnames must contain at least two labels (e.g. "db.example.com").
+	AllowIP           bool     // If true, accepts IPv4 and IPv6 literals in addition to hostnames.
+	AllowedIPVersions []string // A list of allowed IP versions for IP literals, e.g., "IPv4", "IPv6". Optional.
+	AllowedDomains    []string // If set, hostnames must equal or be a subdomain of one of these domains. Optional.
+	ForbidLocalhost   bool     // If true, rejects "localhost", "*.localhost" and loopback addresses (e.g. for production).
+}
+
+// Validate checks if the value associated with the given key is a valid host
+// and meets the configured port, domain and localhost constraints.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *HostValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	host, port, hasPort, err := splitHostPort(strings.TrimSpace(value))
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be a valid host: %v", key, err)
+	}
+
+	if hasPort {
+		if !p.AllowPort && !p.RequirePort {
+			return true, fmt.Errorf("value for key %q must not include a port", key)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return true, fmt.Errorf("value for key %q must have a port between 1 and 65535", key)
+		}
+	} else if p.RequirePort {
+		return true, fmt.Errorf("value for key %q must be in host:port form", key)
+	}
+
+	if ip, err := ParseIP(host); err == nil {
+		if !p.AllowIP {
+			return true, fmt.Errorf("value for key %q must be a hostname, not an IP address", key)
+		}
+		ipPlugin := &IPAddressValidationPlugin{Key: key, AllowedIPVersions: p.AllowedIPVersions}
+		if _, err := ipPlugin.Validate(key, host); err != nil {
+			return true, err
+		}
+		if p.ForbidLocalhost && ip.IsLoopback() {
+			return true, fmt.Errorf("value for key %q must not be a loopback address", key)
+		}
+		return true, nil
+	}
+
+	if !isValidHostname(host) {
+		return true, fmt.Errorf("value for key %q must be a valid hostname", key)
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(host, "."))
+	if p.RequireFQDN && !strings.Contains(name, ".") {
+		return true, fmt.Errorf("value for key %q must be a fully qualified domain name", key)
+	}
+	if p.ForbidLocalhost && (name == "localhost" || strings.HasSuffix(name, ".localhost")) {
+		return true, fmt.Errorf("value for key %q must not be localhost", key)
+	}
+	if len(p.AllowedDomains) > 0 && !matchesDomain(name, p.AllowedDomains) {
+		return true, fmt.Errorf("host for key %q must be within one of the domains %v", key, p.AllowedDomains)
+	}
+
+	return true, nil
+}
+
+// splitHostPort separates an optional port from a host. Unbracketed IPv6 literals
+// such as "::1" are treated as hosts without a port; bracketed hosts must be IPv6 literals.
+//
+// Parameters:
+//   - value: The host, optionally followed by `:port`.
+//
+// Returns:
+//   - string: The host, without brackets.
+//   - string: The port, if present.
+//   - bool: True if a port was present.
+//   - error: An error if the value is empty or malformed.
+func splitHostPort(value string) (string, string, bool, error) {
+	if value == "" {
+		return "", "", false, fmt.Errorf("host is empty")
+	}
+
+	if strings.HasPrefix(value, "[") {
+		var host, port string
+		hasPort := !strings.HasSuffix(value, "]")
+		if hasPort {
+			var err error
+			if host, port, err = net.SplitHostPort(value); err != nil {
+				return "", "", false, err
+			}
+		} else {
+			host = value[1 : len(value)-1]
+		}
+		// Brackets are only valid around IPv6 literals.
+		if addr, err := netip.ParseAddr(host); err != nil || !addr.Is6() || addr.Zone() != "" {
+			return "", "", false, fmt.Errorf("brackets must enclose an IPv6 address, got %q", host)
+		}
+		return host, port, hasPort, nil
+	}
+
+	if strings.Count(value, ":") == 1 {
+		host, port, err := net.SplitHostPort(value)
+		if err != nil {
+			return "", "", false, err
+		}
+		return host, port, true, nil
+	}
+
+	return value, "", false, nil
+}
+
+// isValidHostname reports whether host is a valid RFC 1123 hostname. A single
+// trailing dot (as in a fully qualified "example.com.") is allowed.
+//
+// Parameters:
+//   - host: The hostname to check.
+//
+// Returns:
+//   - bool: True if the hostname is valid.
+func isValidHostname(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return false
+	}
+
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	// An all-numeric top-level label would be confused with an IPv4 address.
+	if _, err := strconv.Atoi(labels[len(labels)-1]); err == nil {
+		return false
+	}
+	return true
+}
+
+// matchesDomain reports whether name equals or is a subdomain of one of the domains.
+//
+// Parameters:
+//   - name: The lower-cased hostname to check.
+//   - domains: The allowed domains; a leading "." or "*." is ignored.
+//
+// Returns:
+//   - bool: True if the name is within one of the domains.
+func matchesDomain(name string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+		domain = strings.TrimPrefix(strings.TrimPrefix(domain, "*"), ".")
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *HostValidationPlugin) Name() string {
+	return "HostValidationPlugin"
+}
@@ -2,21 +2,72 @@ package plugins
 
 import (
 	"fmt"
-	"net"
+	"net/netip"
 	"strings"
+	"sync"
 )
 
 // IPAddressValidationPlugin validates that the value of a specific environment variable
-// key is a valid IP address. It can enforce constraints on IP versions (e.g., IPv4 or IPv6)
-// and ensure that the IP address is private.
+// key is a valid IP address. It can enforce constraints on IP versions (e.g., IPv4 or IPv6),
+// require private or public addresses, reject loopback, link-local or multicast addresses,
+// and restrict addresses to allowed or denied CIDR ranges. With AllowList it accepts a
+// comma-separated list (e.g. `TRUSTED_PROXIES`), and with AllowCIDR it accepts CIDR
+// prefixes such as `10.0.0.0/8` in addition to single addresses. AllowedCIDRs and DeniedCIDRs
+// are parsed on first use, so they must not be changed afterwards.
 type IPAddressValidationPlugin struct {
 	Key               string   // The key of the environment variable to validate.
 	AllowedIPVersions []string // A list of allowed IP versions, e.g., "IPv4", "IPv6".
-	MustBePrivate     bool     // If true, enforces that the IP address must be private.
+	MustBePrivate     bool     // If true, enforces that the IP address must be private (RFC 1918, CGNAT, unique local, loopback or link-local).
+	MustBePublic      bool     // If true, enforces that the IP address must be globally routable (not in any special-purpose range).
+	ForbidLoopback    bool     // If true, rejects loopback addresses (127.0.0.0/8, ::1).
+	ForbidLinkLocal   bool     // If true, rejects link-local addresses (169.254.0.0/16, fe80::/10).
+	ForbidMulticast   bool     // If true, rejects multicast addresses (224.0.0.0/4, ff00::/8).
+	AllowedCIDRs      []string // If set, each address or prefix must lie within one of these CIDR ranges. Optional.
+	DeniedCIDRs       []string // Addresses or prefixes overlapping one of these CIDR ranges are rejected. Optional.
+	AllowList         bool     // If true, accepts a comma-separated list of values, each validated individually.
+	AllowCIDR         bool     // If true, accepts CIDR prefixes (e.g. "10.0.0.0/8") in addition to single addresses.
+
+	cidrsOnce sync.Once      // Guards the parsing of AllowedCIDRs and DeniedCIDRs.
+	allowed   []netip.Prefix // The parsed AllowedCIDRs.
+	denied    []netip.Prefix // The parsed DeniedCIDRs.
+	cidrsErr  error          // The error from parsing AllowedCIDRs or DeniedCIDRs, if any.
 }
 
+// Special-purpose address ranges, parsed once at package initialization.
+var (
+	loopbackRanges  = mustParsePrefixes("127.0.0.0/8", "::1/128")
+	linkLocalRanges = mustParsePrefixes("169.254.0.0/16", "fe80::/10")
+	multicastRanges = mustParsePrefixes("224.0.0.0/4", "ff00::/8")
+
+	// privateRanges holds the ranges that are only reachable within a private network or host.
+	privateRanges = append(mustParsePrefixes(
+		"10.0.0.0/8",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"100.64.0.0/10", // Carrier-grade NAT (RFC 6598).
+		"fc00::/7",      // Unique local addresses.
+	), append(loopbackRanges, linkLocalRanges...)...)
+
+	// nonPublicRanges holds every range that is not globally routable (RFC 6890 and successors).
+	nonPublicRanges = append(mustParsePrefixes(
+		"0.0.0.0/8",
+		"192.0.0.0/24",
+		"192.0.2.0/24",
+		"198.18.0.0/15",
+		"198.51.100.0/24",
+		"203.0.113.0/24",
+		"240.0.0.0/4",
+		"::/128",
+		"64:ff9b:1::/48",
+		"100::/64",
+		"2001::/23",
+		"2001:db8::/32",
+	), append(privateRanges, multicastRanges...)...)
+)
+
 // Validate checks if the value associated with the given key is a valid IP address
-// and meets the specified criteria, such as allowed versions and private IP enforcement.
+// (or list of addresses and prefixes) and meets the specified criteria, such as allowed
+// versions, private or public ranges, special-purpose flags and CIDR sets.
 //
 // Parameters:
 //   - key: The key of the environment variable being validated.
@@ -30,66 +81,225 @@ func (p *IPAddressValidationPlugin) Validate(key, value string) (bool, error) {
 		return false, nil // Plugin does not handle this key.
 	}
 
-	ip := net.ParseIP(strings.TrimSpace(value))
-	if ip == nil {
-		return true, fmt.Errorf("value for key %q must be a valid IP address", key)
+	allowed, denied, err := p.cidrs()
+	if err != nil {
+		return true, fmt.Errorf("invalid configuration for key %q: %v", key, err)
+	}
+
+	elements := []string{value}
+	if p.AllowList {
+		elements = strings.Split(value, ",")
 	}
 
+	for i, element := range elements {
+		element = strings.TrimSpace(element)
+		if problem := p.check(element, allowed, denied); problem != "" {
+			if p.AllowList && len(elements) > 1 {
+				return true, fmt.Errorf("value for key %q %s (entry %d: %q)", key, problem, i+1, element)
+			}
+			return true, fmt.Errorf("value for key %q %s", key, problem)
+		}
+	}
+
+	return true, nil
+}
+
+// cidrs returns AllowedCIDRs and DeniedCIDRs, parsing them on first use.
+//
+// Returns:
+//   - []netip.Prefix: The parsed AllowedCIDRs.
+//   - []netip.Prefix: The parsed DeniedCIDRs.
+//   - error: An error if either list is malformed.
+func (p *IPAddressValidationPlugin) cidrs() ([]netip.Prefix, []netip.Prefix, error) {
+	p.cidrsOnce.Do(func() {
+		if p.allowed, p.cidrsErr = parsePrefixList(p.AllowedCIDRs); p.cidrsErr != nil {
+			p.cidrsErr = fmt.Errorf("invalid AllowedCIDRs: %v", p.cidrsErr)
+			return
+		}
+		if p.denied, p.cidrsErr = parsePrefixList(p.DeniedCIDRs); p.cidrsErr != nil {
+			p.cidrsErr = fmt.Errorf("invalid DeniedCIDRs: %v", p.cidrsErr)
+		}
+	})
+	return p.allowed, p.denied, p.cidrsErr
+}
+
+// check validates a single address or prefix.
+//
+// Parameters:
+//   - value: The trimmed address or prefix.
+//   - allowed: The parsed AllowedCIDRs.
+//   - denied: The parsed DeniedCIDRs.
+//
+// Returns:
+//   - string: A description of the first violated constraint, or an empty string if the value is valid.
+func (p *IPAddressValidationPlugin) check(value string, allowed, denied []netip.Prefix) string {
+	prefix, ok := p.parse(value)
+	if !ok {
+		if p.AllowCIDR {
+			return "must be a valid IP address or CIDR prefix"
+		}
+		return "must be a valid IP address"
+	}
+	addr := prefix.Addr()
+
 	if len(p.AllowedIPVersions) > 0 {
 		validVersion := false
 		for _, version := range p.AllowedIPVersions {
 			switch strings.ToLower(version) {
 			case "ipv4":
-				if ip.To4() != nil {
-					validVersion = true
-				}
+				validVersion = addr.Is4()
 			case "ipv6":
-				if ip.To16() != nil && ip.To4() == nil {
-					validVersion = true
-				}
+				validVersion = addr.Is6()
 			}
 			if validVersion {
 				break
 			}
 		}
 		if !validVersion {
-			return true, fmt.Errorf("value for key %q must be one of the following IP versions: %v", key, p.AllowedIPVersions)
+			return fmt.Sprintf("must be one of the following IP versions: %v", p.AllowedIPVersions)
 		}
 	}
 
-	if p.MustBePrivate {
-		if !isPrivateIP(ip) {
-			return true, fmt.Errorf("value for key %q must be a private IP address", key)
+	if p.MustBePrivate && !withinAny(prefix, privateRanges) {
+		return "must be a private IP address"
+	}
+	if p.MustBePublic && overlapsAny(prefix, nonPublicRanges) {
+		return "must be a public IP address"
+	}
+	if p.ForbidLoopback && overlapsAny(prefix, loopbackRanges) {
+		return "must not be a loopback address"
+	}
+	if p.ForbidLinkLocal && overlapsAny(prefix, linkLocalRanges) {
+		return "must not be a link-local address"
+	}
+	if p.ForbidMulticast && overlapsAny(prefix, multicastRanges) {
+		return "must not be a multicast address"
+	}
+	if len(allowed) > 0 && !withinAny(prefix, allowed) {
+		return fmt.Sprintf("must be within one of the CIDR ranges %v", p.AllowedCIDRs)
+	}
+	if overlapsAny(prefix, denied) {
+		return fmt.Sprintf("must not be within any of the CIDR ranges %v", p.DeniedCIDRs)
+	}
+
+	return ""
+}
+
+// parse parses a single address, or a CIDR prefix when AllowCIDR is set. Addresses are
+// returned as single-address prefixes and IPv4-mapped IPv6 addresses are unmapped.
+//
+// Parameters:
+//   - value: The address or prefix.
+//
+// Returns:
+//   - netip.Prefix: The parsed prefix, masked to its network address.
+//   - bool: False if the value could not be parsed.
+func (p *IPAddressValidationPlugin) parse(value string) (netip.Prefix, bool) {
+	if p.AllowCIDR && strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, false
 		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), true
 	}
 
-	return true, nil
+	addr, err := ParseIP(value)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), true
 }
 
-// isPrivateIP determines if the given IP address belongs to a private IP range.
+// ParseIP parses a single IP address the way IPAddressValidationPlugin does: surrounding
+// whitespace is ignored, zones (e.g. "%eth0") are rejected and IPv4-mapped IPv6 addresses
+// are unmapped, so that "::ffff:10.0.0.1" is 10.0.0.1.
 //
 // Parameters:
-//   - ip: The IP address to check.
+//   - value: The address.
 //
 // Returns:
-//   - bool: True if the IP address is private, otherwise false.
-func isPrivateIP(ip net.IP) bool {
-	privateIPBlocks := []*net.IPNet{}
+//   - netip.Addr: The parsed address.
+//   - error: An error if the value is not a single IP address without a zone.
+func ParseIP(value string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(value))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("%q has a zone", value)
+	}
+	return addr.Unmap(), nil
+}
 
-	// Define private IP ranges.
-	for _, cidr := range []string{
-		"10.0.0.0/8",
-		"172.16.0.0/12",
-		"192.168.0.0/16",
-		"::1/128",
-		"fc00::/7",
-	} {
-		_, block, _ := net.ParseCIDR(cidr)
-		privateIPBlocks = append(privateIPBlocks, block)
+// parsePrefixList parses configured CIDR ranges; single addresses are accepted as
+// single-address ranges.
+//
+// Parameters:
+//   - values: The CIDR ranges or addresses.
+//
+// Returns:
+//   - []netip.Prefix: The parsed ranges.
+//   - error: An error if any value is malformed.
+func parsePrefixList(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
 	}
+	return prefixes, nil
+}
+
+// mustParsePrefixes parses CIDR ranges, panicking on malformed input. It is used for package-level ranges.
+func mustParsePrefixes(values ...string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, len(values))
+	for i, value := range values {
+		prefixes[i] = netip.MustParsePrefix(value)
+	}
+	return prefixes
+}
 
-	for _, block := range privateIPBlocks {
-		if block.Contains(ip) {
+// withinAny reports whether prefix lies entirely within one of the ranges.
+//
+// Parameters:
+//   - prefix: The address or prefix to check.
+//   - ranges: The ranges to check against.
+//
+// Returns:
+//   - bool: True if a range contains the whole prefix.
+func withinAny(prefix netip.Prefix, ranges []netip.Prefix) bool {
+	for _, r := range ranges {
+		if r.Bits() <= prefix.Bits() && r.Contains(prefix.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlapsAny reports whether prefix shares any address with one of the ranges.
+//
+// Parameters:
+//   - prefix: The address or prefix to check.
+//   - ranges: The ranges to check against.
+//
+// Returns:
+//   - bool: True if the prefix overlaps a range.
+func overlapsAny(prefix netip.Prefix, ranges []netip.Prefix) bool {
+	for _, r := range ranges {
+		if r.Overlaps(prefix) {
 			return true
 		}
 	}
@@ -0,0 +1,728 @@
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxSchemaDepth bounds the nesting of subschema evaluation, guarding against `$ref` cycles
+// that do not consume any of the instance.
+const maxSchemaDepth = 128
+
+// jsonSchema validates decoded JSON values against a subset of JSON Schema draft 2020-12.
+//
+// Supported keywords: type, enum, const; minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, multipleOf; minLength, maxLength, pattern; items, prefixItems,
+// contains, minContains, maxContains, minItems, maxItems, uniqueItems; properties,
+// patternProperties, additionalProperties, propertyNames, required, dependentRequired,
+// dependentSchemas, minProperties, maxProperties; allOf, anyOf, oneOf, not, if/then/else;
+// $defs and local `$ref` pointers ("#" or "#/..."). Annotations such as title,
+// description, default and format are ignored. Patterns use Go's RE2 syntax.
+type jsonSchema struct {
+	root any // The decoded root schema, used to resolve `$ref` pointers.
+}
+
+// schemaError describes where and why a JSON instance failed schema validation.
+type schemaError struct {
+	Path    string // The JSON pointer to the failing value; empty for the document root.
+	Message string // A description of the violated constraint.
+}
+
+// Error formats the error as "pointer: message", or just the message at the document root.
+//
+// Returns:
+//   - string: The formatted error.
+func (e *schemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Keyword groups used to check the structure of a schema.
+var (
+	schemaKeywords      = []string{"not", "if", "then", "else", "items", "contains", "additionalProperties", "propertyNames"}
+	schemaArrayKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+	schemaMapKeywords   = []string{"properties", "patternProperties", "$defs", "dependentSchemas"}
+	numberKeywords      = []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"}
+	countKeywords       = []string{"minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties", "minContains", "maxContains"}
+	unsupportedKeywords = []string{"$dynamicRef", "$dynamicAnchor", "$anchor", "$recursiveRef", "unevaluatedItems", "unevaluatedProperties"}
+	jsonSchemaTypes     = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+	// patternCache holds compiled `pattern` and `patternProperties` expressions.
+	patternCache = map[string]*regexp.Regexp{}
+	patternMu    sync.Mutex
+)
+
+// decodeJSON decodes a single JSON value, rejecting trailing data.
+//
+// Parameters:
+//   - data: The JSON text.
+//
+// Returns:
+//   - any: The decoded value, using map[string]any, []any, float64, string, bool and nil.
+//   - error: An error if the text is not a single valid JSON value.
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("empty JSON value")
+		}
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// parseJSONSchema decodes a schema and checks that it only uses supported keywords
+// with well-formed arguments.
+//
+// Parameters:
+//   - data: The schema as JSON text.
+//
+// Returns:
+//   - *jsonSchema: The parsed schema.
+//   - error: An error if the schema is malformed or unsupported.
+func parseJSONSchema(data []byte) (*jsonSchema, error) {
+	root, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	s := &jsonSchema{root: root}
+	if err := s.check(root, "#"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// check verifies the structure of a schema and its subschemas.
+//
+// Parameters:
+//   - schema: The schema to check.
+//   - at: The location of the schema within the root schema, for error messages.
+//
+// Returns:
+//   - error: An error describing the first malformed or unsupported keyword.
+func (s *jsonSchema) check(schema any, at string) error {
+	if _, ok := schema.(bool); ok {
+		return nil
+	}
+	obj, ok := schema.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: schema must be an object or boolean", at)
+	}
+
+	for _, kw := range unsupportedKeywords {
+		if _, ok := obj[kw]; ok {
+			return fmt.Errorf("%s: unsupported keyword %q", at, kw)
+		}
+	}
+	for _, kw := range schemaKeywords {
+		if sub, ok := obj[kw]; ok {
+			if err := s.check(sub, at+"/"+kw); err != nil {
+				return err
+			}
+		}
+	}
+	for _, kw := range schemaArrayKeywords {
+		if v, ok := obj[kw]; ok {
+			subs, ok := v.([]any)
+			if !ok || len(subs) == 0 {
+				return fmt.Errorf("%s/%s: must be a non-empty array of schemas", at, kw)
+			}
+			for i, sub := range subs {
+				if err := s.check(sub, fmt.Sprintf("%s/%s/%d", at, kw, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, kw := range schemaMapKeywords {
+		if v, ok := obj[kw]; ok {
+			subs, ok := v.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s/%s: must be an object of schemas", at, kw)
+			}
+			for name, sub := range subs {
+				if kw == "patternProperties" {
+					if _, err := compilePattern(name); err != nil {
+						return fmt.Errorf("%s/%s: invalid pattern %q: %v", at, kw, name, err)
+					}
+				}
+				if err := s.check(sub, at+"/"+kw+"/"+escapePointer(name)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, kw := range numberKeywords {
+		if v, ok := obj[kw]; ok {
+			n, ok := v.(float64)
+			if !ok {
+				return fmt.Errorf("%s/%s: must be a number", at, kw)
+			}
+			if kw == "multipleOf" && n <= 0 {
+				return fmt.Errorf("%s/%s: must be greater than 0", at, kw)
+			}
+		}
+	}
+	for _, kw := range countKeywords {
+		if v, ok := obj[kw]; ok {
+			if n, ok := v.(float64); !ok || n < 0 || n != math.Trunc(n) {
+				return fmt.Errorf("%s/%s: must be a non-negative integer", at, kw)
+			}
+		}
+	}
+
+	if v, ok := obj["type"]; ok {
+		types, ok := stringList(v)
+		if !ok {
+			return fmt.Errorf("%s/type: must be a string or an array of strings", at)
+		}
+		for _, t := range types {
+			if !contains(jsonSchemaTypes, t) {
+				return fmt.Errorf("%s/type: unknown type %q", at, t)
+			}
+		}
+	}
+	if v, ok := obj["enum"]; ok {
+		if _, ok := v.([]any); !ok {
+			return fmt.Errorf("%s/enum: must be an array", at)
+		}
+	}
+	if v, ok := obj["uniqueItems"]; ok {
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s/uniqueItems: must be a boolean", at)
+		}
+	}
+	if v, ok := obj["required"]; ok {
+		if !isStringArray(v) {
+			return fmt.Errorf("%s/required: must be an array of strings", at)
+		}
+	}
+	if v, ok := obj["dependentRequired"]; ok {
+		deps, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s/dependentRequired: must be an object of string arrays", at)
+		}
+		for _, dep := range deps {
+			if !isStringArray(dep) {
+				return fmt.Errorf("%s/dependentRequired: must be an object of string arrays", at)
+			}
+		}
+	}
+	if v, ok := obj["pattern"]; ok {
+		pattern, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s/pattern: must be a string", at)
+		}
+		if _, err := compilePattern(pattern); err != nil {
+			return fmt.Errorf("%s/pattern: invalid pattern %q: %v", at, pattern, err)
+		}
+	}
+	if v, ok := obj["$ref"]; ok {
+		ref, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s/$ref: must be a string", at)
+		}
+		if _, err := s.resolve(ref); err != nil {
+			return fmt.Errorf("%s/$ref: %v", at, err)
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the subschema referenced by a local `$ref` such as "#/$defs/port".
+//
+// Parameters:
+//   - ref: The reference.
+//
+// Returns:
+//   - any: The referenced schema.
+//   - error: An error if the reference is not local or does not resolve.
+func (s *jsonSchema) resolve(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %q: %v", ref, err)
+	}
+	if pointer == "" {
+		return s.root, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("only JSON pointer references are supported, got %q", ref)
+	}
+
+	current := s.root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("reference %q does not resolve", ref)
+			}
+			current = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("reference %q does not resolve", ref)
+			}
+			current = node[i]
+		default:
+			return nil, fmt.Errorf("reference %q does not resolve", ref)
+		}
+	}
+	return current, nil
+}
+
+// Validate checks a decoded JSON value against the schema.
+//
+// Parameters:
+//   - instance: The decoded JSON value.
+//
+// Returns:
+//   - *schemaError: The first violation found, or nil if the value is valid.
+func (s *jsonSchema) Validate(instance any) *schemaError {
+	return s.validate(s.root, instance, "", 0)
+}
+
+// validate checks an instance against a schema.
+//
+// Parameters:
+//   - schema: The schema, an object or boolean.
+//   - inst: The decoded JSON value.
+//   - path: The JSON pointer to the value.
+//   - depth: The nesting depth of subschema evaluation.
+//
+// Returns:
+//   - *schemaError: The first violation found, or nil if the value is valid.
+func (s *jsonSchema) validate(schema any, inst any, path string, depth int) *schemaError {
+	fail := func(format string, args ...any) *schemaError {
+		return &schemaError{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if depth > maxSchemaDepth {
+		return fail("schema nesting is too deep (recursive $ref?)")
+	}
+	if b, ok := schema.(bool); ok {
+		if !b {
+			return fail("no value is allowed here")
+		}
+		return nil
+	}
+	obj, _ := schema.(map[string]any)
+
+	if ref, ok := obj["$ref"].(string); ok {
+		target, err := s.resolve(ref)
+		if err != nil {
+			return fail("%v", err)
+		}
+		if err := s.validate(target, inst, path, depth+1); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := obj["type"]; ok {
+		types, _ := stringList(v)
+		matched := false
+		for _, t := range types {
+			if hasJSONType(inst, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if len(types) == 1 {
+				return fail("must be of type %s, got %s", types[0], jsonTypeOf(inst))
+			}
+			return fail("must be one of the types %v, got %s", types, jsonTypeOf(inst))
+		}
+	}
+	if v, ok := obj["enum"].([]any); ok {
+		matched := false
+		for _, allowed := range v {
+			if reflect.DeepEqual(inst, allowed) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fail("must be one of %s", compactJSON(v))
+		}
+	}
+	if v, ok := obj["const"]; ok && !reflect.DeepEqual(inst, v) {
+		return fail("must be %s", compactJSON(v))
+	}
+
+	var err *schemaError
+	switch value := inst.(type) {
+	case float64:
+		err = validateNumber(obj, value, fail)
+	case string:
+		err = validateString(obj, value, fail)
+	case []any:
+		err = s.validateArray(obj, value, path, depth, fail)
+	case map[string]any:
+		err = s.validateObject(obj, value, path, depth, fail)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.validateCombinators(obj, inst, path, depth, fail)
+}
+
+// validateNumber applies the numeric keywords.
+func validateNumber(obj map[string]any, n float64, fail func(string, ...any) *schemaError) *schemaError {
+	if min, ok := obj["minimum"].(float64); ok && n < min {
+		return fail("must be >= %s", formatNumber(min))
+	}
+	if max, ok := obj["maximum"].(float64); ok && n > max {
+		return fail("must be <= %s", formatNumber(max))
+	}
+	if min, ok := obj["exclusiveMinimum"].(float64); ok && n <= min {
+		return fail("must be > %s", formatNumber(min))
+	}
+	if max, ok := obj["exclusiveMaximum"].(float64); ok && n >= max {
+		return fail("must be < %s", formatNumber(max))
+	}
+	if m, ok := obj["multipleOf"].(float64); ok {
+		q := n / m
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			return fail("must be a multiple of %s", formatNumber(m))
+		}
+	}
+	return nil
+}
+
+// validateString applies the string keywords. Lengths are counted in characters.
+func validateString(obj map[string]any, str string, fail func(string, ...any) *schemaError) *schemaError {
+	length := utf8.RuneCountInString(str)
+	if min, ok := obj["minLength"].(float64); ok && length < int(min) {
+		return fail("must be at least %d characters long", int(min))
+	}
+	if max, ok := obj["maxLength"].(float64); ok && length > int(max) {
+		return fail("must be at most %d characters long", int(max))
+	}
+	if pattern, ok := obj["pattern"].(string); ok {
+		re, err := compilePattern(pattern)
+		if err != nil || !re.MatchString(str) {
+			return fail("must match pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// validateArray applies the array keywords.
+func (s *jsonSchema) validateArray(obj map[string]any, items []any, path string, depth int, fail func(string, ...any) *schemaError) *schemaError {
+	if min, ok := obj["minItems"].(float64); ok && len(items) < int(min) {
+		return fail("must contain at least %d items, got %d", int(min), len(items))
+	}
+	if max, ok := obj["maxItems"].(float64); ok && len(items) > int(max) {
+		return fail("must contain at most %d items, got %d", int(max), len(items))
+	}
+	if unique, _ := obj["uniqueItems"].(bool); unique {
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if reflect.DeepEqual(items[i], items[j]) {
+					return fail("items %d and %d must be unique", i, j)
+				}
+			}
+		}
+	}
+
+	prefixItems, _ := obj["prefixItems"].([]any)
+	for i, item := range items {
+		itemPath := path + "/" + strconv.Itoa(i)
+		if i < len(prefixItems) {
+			if err := s.validate(prefixItems[i], item, itemPath, depth+1); err != nil {
+				return err
+			}
+		} else if sub, ok := obj["items"]; ok {
+			if err := s.validate(sub, item, itemPath, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	if sub, ok := obj["contains"]; ok {
+		matches := 0
+		for i, item := range items {
+			if s.validate(sub, item, path+"/"+strconv.Itoa(i), depth+1) == nil {
+				matches++
+			}
+		}
+		min := 1
+		if v, ok := obj["minContains"].(float64); ok {
+			min = int(v)
+		}
+		if matches < min {
+			return fail("must contain at least %d items matching the contains schema, got %d", min, matches)
+		}
+		if max, ok := obj["maxContains"].(float64); ok && matches > int(max) {
+			return fail("must contain at most %d items matching the contains schema, got %d", int(max), matches)
+		}
+	}
+	return nil
+}
+
+// validateObject applies the object keywords. Properties are checked in sorted order so
+// that the reported violation is deterministic.
+func (s *jsonSchema) validateObject(obj map[string]any, props map[string]any, path string, depth int, fail func(string, ...any) *schemaError) *schemaError {
+	if min, ok := obj["minProperties"].(float64); ok && len(props) < int(min) {
+		return fail("must have at least %d properties, got %d", int(min), len(props))
+	}
+	if max, ok := obj["maxProperties"].(float64); ok && len(props) > int(max) {
+		return fail("must have at most %d properties, got %d", int(max), len(props))
+	}
+	if required, ok := stringList(obj["required"]); ok {
+		for _, name := range required {
+			if _, ok := props[name]; !ok {
+				return fail("missing required property %q", name)
+			}
+		}
+	}
+	if deps, ok := obj["dependentRequired"].(map[string]any); ok {
+		for _, name := range sortedKeys(deps) {
+			if _, present := props[name]; !present {
+				continue
+			}
+			required, _ := stringList(deps[name])
+			for _, dep := range required {
+				if _, ok := props[dep]; !ok {
+					return fail("property %q requires property %q", name, dep)
+				}
+			}
+		}
+	}
+	if deps, ok := obj["dependentSchemas"].(map[string]any); ok {
+		for _, name := range sortedKeys(deps) {
+			if _, present := props[name]; present {
+				if err := s.validate(deps[name], props, path, depth+1); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	properties, _ := obj["properties"].(map[string]any)
+	patternProperties, _ := obj["patternProperties"].(map[string]any)
+	additional, hasAdditional := obj["additionalProperties"]
+	propertyNames, hasPropertyNames := obj["propertyNames"]
+
+	for _, name := range sortedKeys(props) {
+		propPath := path + "/" + escapePointer(name)
+		if hasPropertyNames {
+			if err := s.validate(propertyNames, name, propPath, depth+1); err != nil {
+				return &schemaError{Path: propPath, Message: "invalid property name: " + err.Message}
+			}
+		}
+
+		evaluated := false
+		if sub, ok := properties[name]; ok {
+			evaluated = true
+			if err := s.validate(sub, props[name], propPath, depth+1); err != nil {
+				return err
+			}
+		}
+		for _, pattern := range sortedKeys(patternProperties) {
+			if re, err := compilePattern(pattern); err == nil && re.MatchString(name) {
+				evaluated = true
+				if err := s.validate(patternProperties[pattern], props[name], propPath, depth+1); err != nil {
+					return err
+				}
+			}
+		}
+		if !evaluated && hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				return fail("property %q is not allowed", name)
+			}
+			if err := s.validate(additional, props[name], propPath, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateCombinators applies allOf, anyOf, oneOf, not and if/then/else.
+func (s *jsonSchema) validateCombinators(obj map[string]any, inst any, path string, depth int, fail func(string, ...any) *schemaError) *schemaError {
+	if subs, ok := obj["allOf"].([]any); ok {
+		for _, sub := range subs {
+			if err := s.validate(sub, inst, path, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	if subs, ok := obj["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range subs {
+			if s.validate(sub, inst, path, depth+1) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fail("must match at least one schema in anyOf")
+		}
+	}
+	if subs, ok := obj["oneOf"].([]any); ok {
+		matches := 0
+		for _, sub := range subs {
+			if s.validate(sub, inst, path, depth+1) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fail("must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+	if sub, ok := obj["not"]; ok && s.validate(sub, inst, path, depth+1) == nil {
+		return fail("must not match the schema in not")
+	}
+	if cond, ok := obj["if"]; ok {
+		branch := "else"
+		if s.validate(cond, inst, path, depth+1) == nil {
+			branch = "then"
+		}
+		if sub, ok := obj[branch]; ok {
+			if err := s.validate(sub, inst, path, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasJSONType reports whether a decoded value has the given JSON Schema type. Numbers
+// with a zero fractional part are integers.
+func hasJSONType(v any, t string) bool {
+	switch t {
+	case "integer":
+		n, ok := v.(float64)
+		return ok && n == math.Trunc(n) && !math.IsInf(n, 0)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return jsonTypeOf(v) == t
+	}
+}
+
+// jsonTypeOf returns the JSON Schema type name of a decoded value.
+func jsonTypeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// compilePattern compiles a regular expression, caching the result.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	patternMu.Lock()
+	defer patternMu.Unlock()
+	if re, ok := patternCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache[pattern] = re
+	return re, nil
+}
+
+// stringList converts a JSON string or array of strings to a slice.
+func stringList(v any) ([]string, bool) {
+	switch value := v.(type) {
+	case string:
+		return []string{value}, true
+	case []any:
+		list := make([]string, len(value))
+		for i, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			list[i] = s
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// isStringArray reports whether a decoded value is an array of strings.
+func isStringArray(v any) bool {
+	if _, ok := v.([]any); !ok {
+		return false
+	}
+	_, ok := stringList(v)
+	return ok
+}
+
+// sortedKeys returns the keys of a JSON object in sorted order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a property name for use as a JSON pointer token.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// formatNumber formats a JSON number without a trailing ".0" or exponent noise.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// compactJSON formats a decoded value as compact JSON for error messages.
+func compactJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// contains reports whether values contains s.
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
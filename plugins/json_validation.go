@@ -0,0 +1,129 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// JSONValidationPlugin validates that the value of a specific environment variable key
+// is well-formed JSON, such as a feature-flag map or a service routing table. It can
+// optionally validate the value against a JSON Schema (a subset of draft 2020-12, see
+// jsonSchema), given inline or as a file, without any network access. Schema violations
+// are reported with the JSON pointer of the failing value, e.g. "/routes/0/port". The
+// schema is parsed on first use, so Schema, SchemaFile and BaseDir must not be changed afterwards.
+type JSONValidationPlugin struct {
+	Key        string // The key of the environment variable to validate.
+	Schema     string // A JSON Schema the value must satisfy, e.g. embedded with go:embed. Optional.
+	SchemaFile string // The path of a JSON Schema file the value must satisfy; used if Schema is empty. Optional.
+	BaseDir    string // The directory a relative SchemaFile is resolved against; defaults to the `.env` file's directory. Optional.
+
+	schemaMu sync.Mutex                   // Guards schemas.
+	schemas  map[string]*loadedJSONSchema // The parsed schemas, keyed by the resolved SchemaFile path ("" for Schema).
+}
+
+// loadedJSONSchema holds the result of parsing a schema.
+type loadedJSONSchema struct {
+	schema *jsonSchema // The parsed schema, or nil if none is configured.
+	err    error       // The error from reading or parsing the schema, if any.
+}
+
+// Validate checks if the value associated with the given key is well-formed JSON
+// and, if a schema is configured, that it satisfies the schema.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *JSONValidationPlugin) Validate(key, value string) (bool, error) {
+	return p.ValidateContext(context.Background(), key, value)
+}
+
+// ValidateContext validates the value like Validate, using the context to resolve a
+// relative SchemaFile against the `.env` file's directory.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or the context is done, or nil if it passes validation.
+func (p *JSONValidationPlugin) ValidateContext(ctx context.Context, key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+	if err := ctx.Err(); err != nil {
+		return true, err
+	}
+
+	instance, err := decodeJSON([]byte(value))
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be valid JSON: %v", key, err)
+	}
+
+	schema, err := p.loadSchema(ctx)
+	if err != nil {
+		return true, fmt.Errorf("invalid JSON schema for key %q: %v", key, err)
+	}
+	if schema == nil {
+		return true, nil
+	}
+
+	if schemaErr := schema.Validate(instance); schemaErr != nil {
+		return true, fmt.Errorf("value for key %q does not match the JSON schema: %v", key, schemaErr)
+	}
+
+	return true, nil
+}
+
+// loadSchema returns the configured schema, reading and parsing it on first use.
+//
+// Parameters:
+//   - ctx: The context, used to resolve a relative SchemaFile.
+//
+// Returns:
+//   - *jsonSchema: The parsed schema, or nil if none is configured.
+//   - error: An error if the schema cannot be read or is malformed.
+func (p *JSONValidationPlugin) loadSchema(ctx context.Context) (*jsonSchema, error) {
+	if p.Schema == "" && p.SchemaFile == "" {
+		return nil, nil
+	}
+	path := ""
+	if p.Schema == "" {
+		path = resolvePath(ctx, p.BaseDir, p.SchemaFile)
+	}
+
+	p.schemaMu.Lock()
+	defer p.schemaMu.Unlock()
+	if loaded, ok := p.schemas[path]; ok {
+		return loaded.schema, loaded.err
+	}
+
+	loaded := &loadedJSONSchema{}
+	data := []byte(p.Schema)
+	if path != "" {
+		data, loaded.err = os.ReadFile(path)
+	}
+	if loaded.err == nil {
+		loaded.schema, loaded.err = parseJSONSchema(data)
+	}
+	if p.schemas == nil {
+		p.schemas = make(map[string]*loadedJSONSchema)
+	}
+	p.schemas[path] = loaded
+	return loaded.schema, loaded.err
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *JSONValidationPlugin) Name() string {
+	return "JSONValidationPlugin"
+}
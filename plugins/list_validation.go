@@ -0,0 +1,174 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ListValidationPlugin validates that the value of a specific environment variable key
+// is a list of elements joined by a separator, such as `CORS_ORIGINS=https://a,https://b`
+// or `KAFKA_BROKERS=h1:9092,h2:9092`. It can enforce the number of elements and their
+// uniqueness, and validates each element with an inner plugin (URL, IP, enum, ...).
+type ListValidationPlugin struct {
+	Key       string           // The key of the environment variable to validate.
+	Separator string           // The separator between elements; defaults to ",".
+	TrimSpace bool             // If true, leading and trailing whitespace is removed from each element.
+	MinCount  int              // The minimum number of elements; 0 means no minimum.
+	MaxCount  int              // The maximum number of elements; 0 means no maximum.
+	Unique    bool             // If true, elements must not repeat.
+	Element   ValidationPlugin // The plugin applied to each element; it must be configured with the same Key. Optional.
+}
+
+// Validate checks if the value associated with the given key is a list that meets the
+// count and uniqueness constraints and whose elements all pass the element plugin.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *ListValidationPlugin) Validate(key, value string) (bool, error) {
+	return p.ValidateContext(context.Background(), key, value)
+}
+
+// ValidateContext validates the list like Validate, passing the context to a context-aware
+// element plugin and stopping between elements once the context is done.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or the context is done, or nil if it passes validation.
+func (p *ListValidationPlugin) ValidateContext(ctx context.Context, key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	elements := p.split(value)
+
+	if p.MinCount > 0 && len(elements) < p.MinCount {
+		return true, fmt.Errorf("value for key %q must contain at least %d elements, got %d", key, p.MinCount, len(elements))
+	}
+	if p.MaxCount > 0 && len(elements) > p.MaxCount {
+		return true, fmt.Errorf("value for key %q must contain at most %d elements, got %d", key, p.MaxCount, len(elements))
+	}
+
+	seen := make(map[string]int, len(elements))
+	for i, element := range elements {
+		if element == "" {
+			return true, fmt.Errorf("value for key %q must not contain empty elements (element %d)", key, i+1)
+		}
+		if p.Unique {
+			if first, ok := seen[element]; ok {
+				return true, fmt.Errorf("value for key %q contains duplicate element %q (elements %d and %d)", key, element, first+1, i+1)
+			}
+			seen[element] = i
+		}
+	}
+
+	if p.Element == nil {
+		return true, nil
+	}
+
+	element := WithContext(p.Element)
+	for i, value := range elements {
+		handled, err := element.ValidateContext(ctx, key, value)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return true, ctxErr
+		}
+		if err != nil {
+			return true, fmt.Errorf("element %d (%q) of key %q is invalid: %w", i+1, value, key, err)
+		}
+		if !handled {
+			return true, fmt.Errorf("element plugin %s does not handle key %q", p.Element.Name(), key)
+		}
+	}
+
+	return true, nil
+}
+
+// Fix applies the element plugin's fixes to each element and rejoins the list. It only
+// applies when the element plugin implements FixerPlugin.
+//
+// Parameters:
+//   - key: The key of the environment variable being fixed.
+//   - value: The current value of the environment variable.
+//
+// Returns:
+//   - string: The fixed value, or the original value if no fix applies.
+//   - bool: True if the value was changed.
+func (p *ListValidationPlugin) Fix(key, value string) (string, bool) {
+	fixer, ok := p.Element.(FixerPlugin)
+	if key != p.Key || !ok {
+		return value, false
+	}
+
+	elements := p.split(value)
+	changed := false
+	for i, element := range elements {
+		if fixed, ok := fixer.Fix(key, element); ok {
+			elements[i] = fixed
+			changed = true
+		}
+	}
+	if !changed {
+		return value, false
+	}
+	return strings.Join(elements, p.separator()), true
+}
+
+// Elements returns the elements of a list value, split and trimmed as configured. An empty
+// value has no elements. The value is not validated.
+//
+// Parameters:
+//   - value: The list value.
+//
+// Returns:
+//   - []string: The elements.
+func (p *ListValidationPlugin) Elements(value string) []string {
+	return p.split(value)
+}
+
+// split separates the value into elements, trimming them if configured. An empty
+// value has no elements.
+//
+// Parameters:
+//   - value: The list value.
+//
+// Returns:
+//   - []string: The elements.
+func (p *ListValidationPlugin) split(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	elements := strings.Split(value, p.separator())
+	if p.TrimSpace {
+		for i, element := range elements {
+			elements[i] = strings.TrimSpace(element)
+		}
+	}
+	return elements
+}
+
+// separator returns the configured separator.
+func (p *ListValidationPlugin) separator() string {
+	if p.Separator == "" {
+		return ","
+	}
+	return p.Separator
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *ListValidationPlugin) Name() string {
+	return "ListValidationPlugin"
+}
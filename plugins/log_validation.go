@@ -0,0 +1,320 @@
+package plugins
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logLibraries lists the supported logging libraries.
+var logLibraries = []string{"logrus", "slog", "zap"}
+
+// logLevels lists the level names understood by each supported logging library.
+// Names are matched case-insensitively; "warning" is an alias for "warn" in every library.
+var logLevels = map[string][]string{
+	"logrus": {"trace", "debug", "info", "warn", "error", "fatal", "panic"},
+	"slog":   {"debug", "info", "warn", "error"},
+	"zap":    {"debug", "info", "warn", "error", "dpanic", "panic", "fatal"},
+}
+
+// logLevelAliases maps alternative level names onto the names in logLevels.
+var logLevelAliases = map[string]string{
+	"warning": "warn",
+}
+
+// logLevelSeverity orders level names from most to least verbose. Libraries disagree on
+// whether "panic" or "fatal" is more severe, so both have the same severity.
+var logLevelSeverity = map[string]int{
+	"trace":  0,
+	"debug":  1,
+	"info":   2,
+	"warn":   3,
+	"error":  4,
+	"dpanic": 5,
+	"panic":  6,
+	"fatal":  6,
+}
+
+// logFormats lists the output formats supported by each logging library: logrus formatters,
+// slog handlers and zap encoders.
+var logFormats = map[string][]string{
+	"logrus": {"json", "text"},
+	"slog":   {"json", "text"},
+	"zap":    {"json", "console"},
+}
+
+// logFormatAliases maps alternative format names onto the names in logFormats.
+var logFormatAliases = map[string]string{
+	"logfmt": "text",
+}
+
+// LoggingPreset returns plugins that validate the conventional log level and log format keys,
+// e.g. `LOG_LEVEL=info` and `LOG_FORMAT=json`, accepting the names of every supported logging
+// library. An empty key skips the corresponding plugin.
+//
+// Parameters:
+//   - levelKey: The key holding the log level, e.g. "LOG_LEVEL".
+//   - formatKey: The key holding the log format, e.g. "LOG_FORMAT".
+//
+// Returns:
+//   - []ValidationPlugin: The configured plugins.
+func LoggingPreset(levelKey, formatKey string) []ValidationPlugin {
+	var preset []ValidationPlugin
+	if levelKey != "" {
+		preset = append(preset, &LogLevelValidationPlugin{Key: levelKey})
+	}
+	if formatKey != "" {
+		preset = append(preset, &LogFormatValidationPlugin{Key: formatKey})
+	}
+	return preset
+}
+
+// LogLevelValidationPlugin validates that the value of a specific environment variable key,
+// such as `LOG_LEVEL`, is a level name understood by the logging libraries in use: logrus,
+// log/slog or zap. Names are case-insensitive and "warning" is accepted for "warn". The
+// LogrusLevel and SlogLevel methods map a valid value to the library's level type.
+type LogLevelValidationPlugin struct {
+	Key       string   // The key of the environment variable to validate.
+	Libraries []string // The libraries whose level names are accepted: "logrus", "slog" and/or "zap"; defaults to all three.
+	MinLevel  string   // If set, the level must be at least as severe as this one, e.g. "info" to forbid debug logging. Optional.
+}
+
+// Validate checks if the value associated with the given key is a log level name accepted
+// by one of the configured libraries and at least as severe as MinLevel.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *LogLevelValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	_, err := p.level(key, value)
+	return true, err
+}
+
+// LogrusLevel returns the logrus level for a valid value. zap's "dpanic" maps to logrus.ErrorLevel,
+// since logrus has no development-only panic level.
+//
+// Parameters:
+//   - value: The log level name.
+//
+// Returns:
+//   - logrus.Level: The corresponding logrus level.
+//   - error: An error if the value is not valid.
+func (p *LogLevelValidationPlugin) LogrusLevel(value string) (logrus.Level, error) {
+	level, err := p.level(p.Key, value)
+	if err != nil {
+		return 0, err
+	}
+	if level == "dpanic" {
+		return logrus.ErrorLevel, nil
+	}
+	return logrus.ParseLevel(level)
+}
+
+// SlogLevel returns the slog level for a valid value. slog only defines debug, info, warn and
+// error, so "trace" maps to slog.LevelDebug-4 and "dpanic", "panic" and "fatal" map to
+// slog.LevelError+4, following the spacing of slog's own levels.
+//
+// Parameters:
+//   - value: The log level name.
+//
+// Returns:
+//   - slog.Level: The corresponding slog level.
+//   - error: An error if the value is not valid.
+func (p *LogLevelValidationPlugin) SlogLevel(value string) (slog.Level, error) {
+	level, err := p.level(p.Key, value)
+	if err != nil {
+		return 0, err
+	}
+	switch level {
+	case "trace":
+		return slog.LevelDebug - 4, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelError + 4, nil
+	}
+}
+
+// level validates value and returns its canonical, lower-case level name.
+//
+// Parameters:
+//   - key: The key of the environment variable, used in error messages.
+//   - value: The log level name.
+//
+// Returns:
+//   - string: The canonical level name.
+//   - error: An error if the value is not valid.
+func (p *LogLevelValidationPlugin) level(key, value string) (string, error) {
+	accepted, err := acceptedNames(logLevels, p.Libraries)
+	if err != nil {
+		return "", fmt.Errorf("invalid libraries for key %q: %v", key, err)
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return logLevelSeverity[accepted[i]] < logLevelSeverity[accepted[j]]
+	})
+
+	level := canonicalName(value, logLevelAliases)
+	if !contains(accepted, level) {
+		return "", fmt.Errorf("value for key %q must be one of the log levels %v, got %q", key, accepted, value)
+	}
+
+	if p.MinLevel != "" {
+		minimum := canonicalName(p.MinLevel, logLevelAliases)
+		if _, ok := logLevelSeverity[minimum]; !ok {
+			return "", fmt.Errorf("invalid minimum log level %q for key %q", p.MinLevel, key)
+		}
+		if logLevelSeverity[level] < logLevelSeverity[minimum] {
+			return "", fmt.Errorf("value for key %q must be %q or more severe, got %q", key, minimum, value)
+		}
+	}
+
+	return level, nil
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *LogLevelValidationPlugin) Name() string {
+	return "LogLevelValidationPlugin"
+}
+
+// LogFormatValidationPlugin validates that the value of a specific environment variable key,
+// such as `LOG_FORMAT`, is an output format supported by the logging libraries in use:
+// "json" and "text" for logrus and log/slog, "json" and "console" for zap. Names are
+// case-insensitive and "logfmt" is accepted for "text".
+type LogFormatValidationPlugin struct {
+	Key            string   // The key of the environment variable to validate.
+	Libraries      []string // The libraries whose formats are accepted: "logrus", "slog" and/or "zap"; defaults to all three.
+	AllowedFormats []string // If set, replaces the formats of Libraries, e.g. []string{"json"} to require structured logs. Optional.
+}
+
+// Validate checks if the value associated with the given key is a supported log format.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *LogFormatValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	_, err := p.format(key, value)
+	return true, err
+}
+
+// Canonical returns the canonical, lower-case name of a valid format, with aliases resolved,
+// e.g. "text" for "LOGFMT".
+//
+// Parameters:
+//   - value: The log format name.
+//
+// Returns:
+//   - string: The canonical format name.
+//   - error: An error if the value is not valid.
+func (p *LogFormatValidationPlugin) Canonical(value string) (string, error) {
+	return p.format(p.Key, value)
+}
+
+// format validates value and returns its canonical format name.
+//
+// Parameters:
+//   - key: The key of the environment variable, used in error messages.
+//   - value: The log format name.
+//
+// Returns:
+//   - string: The canonical format name.
+//   - error: An error if the value is not valid.
+func (p *LogFormatValidationPlugin) format(key, value string) (string, error) {
+	accepted, err := acceptedNames(logFormats, p.Libraries)
+	if err != nil {
+		return "", fmt.Errorf("invalid libraries for key %q: %v", key, err)
+	}
+	if len(p.AllowedFormats) > 0 {
+		accepted = nil
+		for _, allowed := range p.AllowedFormats {
+			accepted = append(accepted, canonicalName(allowed, logFormatAliases))
+		}
+	}
+
+	format := canonicalName(value, logFormatAliases)
+	if !contains(accepted, format) {
+		return "", fmt.Errorf("value for key %q must be one of the log formats %v, got %q", key, accepted, value)
+	}
+	return format, nil
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *LogFormatValidationPlugin) Name() string {
+	return "LogFormatValidationPlugin"
+}
+
+// acceptedNames returns the union of the names supported by the given libraries, in the
+// order they are first listed.
+//
+// Parameters:
+//   - names: The names supported by each library.
+//   - libraries: The libraries to include; all libraries if empty.
+//
+// Returns:
+//   - []string: The accepted names.
+//   - error: An error if a library is unknown.
+func acceptedNames(names map[string][]string, libraries []string) ([]string, error) {
+	if len(libraries) == 0 {
+		libraries = logLibraries
+	}
+
+	var accepted []string
+	for _, library := range libraries {
+		libraryNames, ok := names[strings.ToLower(library)]
+		if !ok {
+			return nil, fmt.Errorf("unknown logging library %q; expected one of %v", library, logLibraries)
+		}
+		for _, name := range libraryNames {
+			if !contains(accepted, name) {
+				accepted = append(accepted, name)
+			}
+		}
+	}
+	return accepted, nil
+}
+
+// canonicalName lower-cases and trims a name and resolves aliases.
+//
+// Parameters:
+//   - name: The name to canonicalize.
+//   - aliases: Alternative names mapped onto canonical names.
+//
+// Returns:
+//   - string: The canonical name.
+func canonicalName(name string, aliases map[string]string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if canonical, ok := aliases[name]; ok {
+		return canonical
+	}
+	return name
+}
@@ -0,0 +1,186 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathValidationPlugin validates that the value of a specific environment variable key
+// is a filesystem path, such as `SSL_CERT_PATH`, `LOG_DIR` or `GOOGLE_APPLICATION_CREDENTIALS`.
+// It can check that the path exists and is a file or directory, that it is readable or
+// writable, whether it is absolute or relative, its extension and its size. Relative paths
+// are resolved against BaseDir, or else the directory of the `.env` file being validated.
+type PathValidationPlugin struct {
+	Key               string   // The key of the environment variable to validate.
+	MustExist         bool     // If true, the path must exist. Type, permission and size checks only apply to existing paths.
+	Type              string   // The required kind of path: "file" or "dir". Optional.
+	Readable          bool     // If true, the path must be readable by the current process.
+	Writable          bool     // If true, the path must be writable by the current process.
+	RequireAbsolute   bool     // If true, the path must be absolute.
+	RequireRelative   bool     // If true, the path must be relative.
+	AllowedExtensions []string // If set, the path must have one of these extensions, e.g., ".json", "pem". Optional.
+	MaxSize           int64    // The maximum size of a file in bytes; 0 means no limit.
+	BaseDir           string   // The directory relative paths are resolved against; defaults to the `.env` file's directory. Optional.
+}
+
+// Validate checks if the value associated with the given key is a path that meets the
+// configured constraints. Relative paths are resolved against BaseDir, or the working
+// directory if BaseDir is empty.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *PathValidationPlugin) Validate(key, value string) (bool, error) {
+	return p.ValidateContext(context.Background(), key, value)
+}
+
+// ValidateContext validates the path like Validate, resolving relative paths against the
+// directory of the `.env` file carried by the context when BaseDir is empty.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or the context is done, or nil if it passes validation.
+func (p *PathValidationPlugin) ValidateContext(ctx context.Context, key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+	if err := ctx.Err(); err != nil {
+		return true, err
+	}
+
+	if value == "" {
+		return true, fmt.Errorf("value for key %q must be a path", key)
+	}
+	if p.RequireAbsolute && !filepath.IsAbs(value) {
+		return true, fmt.Errorf("value for key %q must be an absolute path", key)
+	}
+	if p.RequireRelative && filepath.IsAbs(value) {
+		return true, fmt.Errorf("value for key %q must be a relative path", key)
+	}
+	if len(p.AllowedExtensions) > 0 && !hasExtension(value, p.AllowedExtensions) {
+		return true, fmt.Errorf("value for key %q must have one of the extensions %v", key, p.AllowedExtensions)
+	}
+
+	path := resolvePath(ctx, p.BaseDir, value)
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if p.MustExist {
+			return true, fmt.Errorf("path %q for key %q does not exist", path, key)
+		}
+		return true, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("path %q for key %q cannot be accessed: %v", path, key, err)
+	}
+
+	switch strings.ToLower(p.Type) {
+	case "file":
+		if !info.Mode().IsRegular() {
+			return true, fmt.Errorf("path %q for key %q must be a file", path, key)
+		}
+	case "dir", "directory":
+		if !info.IsDir() {
+			return true, fmt.Errorf("path %q for key %q must be a directory", path, key)
+		}
+	}
+
+	if p.MaxSize > 0 && info.Mode().IsRegular() && info.Size() > p.MaxSize {
+		return true, fmt.Errorf("file %q for key %q must be at most %d bytes, got %d", path, key, p.MaxSize, info.Size())
+	}
+	if p.Readable {
+		if err := checkReadable(path, info); err != nil {
+			return true, fmt.Errorf("path %q for key %q is not readable: %v", path, key, err)
+		}
+	}
+	if p.Writable {
+		if err := checkWritable(path, info); err != nil {
+			return true, fmt.Errorf("path %q for key %q is not writable: %v", path, key, err)
+		}
+	}
+
+	return true, nil
+}
+
+// resolvePath returns the path to check, joining relative paths with the base directory.
+//
+// Parameters:
+//   - ctx: The context, which may carry the `.env` file path.
+//   - baseDir: The directory relative paths are resolved against; if empty, the `.env` file's directory is used.
+//   - value: The path as written.
+//
+// Returns:
+//   - string: The cleaned path to check.
+func resolvePath(ctx context.Context, baseDir, value string) string {
+	if filepath.IsAbs(value) {
+		return filepath.Clean(value)
+	}
+	if baseDir == "" {
+		if envFile, ok := EnvFileFromContext(ctx); ok {
+			baseDir = filepath.Dir(envFile)
+		}
+	}
+	return filepath.Join(baseDir, value)
+}
+
+// hasExtension reports whether path has one of the extensions, compared case-insensitively.
+//
+// Parameters:
+//   - path: The path to check.
+//   - extensions: The allowed extensions, with or without a leading dot.
+//
+// Returns:
+//   - bool: True if the path's extension is allowed.
+func hasExtension(path string, extensions []string) bool {
+	ext := filepath.Ext(path)
+	for _, allowed := range extensions {
+		if !strings.HasPrefix(allowed, ".") {
+			allowed = "." + allowed
+		}
+		if strings.EqualFold(ext, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReadable verifies that a file can be opened for reading or a directory can be listed.
+//
+// Parameters:
+//   - path: The existing path.
+//   - info: The path's file info.
+//
+// Returns:
+//   - error: An error if the path cannot be read.
+func checkReadable(path string, info fs.FileInfo) error {
+	if info.IsDir() {
+		_, err := os.ReadDir(path)
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *PathValidationPlugin) Name() string {
+	return "PathValidationPlugin"
+}
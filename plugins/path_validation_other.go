@@ -0,0 +1,24 @@
+//go:build !unix
+
+package plugins
+
+import (
+	"io/fs"
+)
+
+// checkWritable verifies that a path is not read-only. Only the permission bits are
+// checked (on Windows, the read-only attribute of files); nothing is created or opened
+// for writing, so the check has no side effects.
+//
+// Parameters:
+//   - path: The existing path.
+//   - info: The path's file info.
+//
+// Returns:
+//   - error: An error if the path cannot be written.
+func checkWritable(path string, info fs.FileInfo) error {
+	if info.Mode().Perm()&0200 == 0 {
+		return &fs.PathError{Op: "access", Path: path, Err: fs.ErrPermission}
+	}
+	return nil
+}
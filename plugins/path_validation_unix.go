@@ -0,0 +1,23 @@
+//go:build unix
+
+package plugins
+
+import (
+	"io/fs"
+
+	"golang.org/x/sys/unix"
+)
+
+// checkWritable verifies with access(2) that the current process may write to a file,
+// or create files in a directory. Nothing is created or opened for writing, so the
+// check has no side effects.
+//
+// Parameters:
+//   - path: The existing path.
+//   - info: The path's file info.
+//
+// Returns:
+//   - error: An error if the path cannot be written.
+func checkWritable(path string, info fs.FileInfo) error {
+	return unix.Access(path, unix.W_OK)
+}
@@ -0,0 +1,211 @@
+package plugins
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonSecretsList holds the placeholders and common passwords rejected by ForbidPlaceholders.
+//
+//go:embed common_secrets.txt
+var commonSecretsList string
+
+// commonSecrets is the lower-cased set of entries in commonSecretsList.
+var commonSecrets = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, line := range strings.Split(commonSecretsList, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			set[strings.ToLower(line)] = true
+		}
+	}
+	return set
+}()
+
+// placeholderMarkers are substrings that indicate a value was never filled in. They are long
+// enough not to occur by chance in a random secret.
+var placeholderMarkers = []string{"changeme", "change_me", "change-me", "replaceme", "replace_me", "replace-me", "placeholder"}
+
+// placeholderWords indicate a value was never filled in when they are the whole value or a
+// word delimited by non-alphanumeric characters, as in "your_password_here" or "TODO-set-me".
+// They are short enough to occur by chance in a random secret, so they are not matched as substrings.
+var placeholderWords = []string{"your", "todo", "fixme"}
+
+// SecretValidationPlugin validates that the value of a specific environment variable key,
+// such as `API_KEY` or `DB_PASSWORD`, looks like a real secret rather than a placeholder
+// such as `changeme`, `xxx` or `TODO`. It can enforce a minimum length and Shannon entropy,
+// reject placeholders and common passwords, require character classes, and decode base64
+// or hex secrets to enforce a minimum key size (e.g. 32-byte JWT signing keys).
+// Error messages never include the secret itself.
+type SecretValidationPlugin struct {
+	Key                 string   // The key of the environment variable to validate.
+	MinLength           int      // The minimum length in characters; 0 means no minimum.
+	MinEntropyBits      float64  // The minimum Shannon entropy of the whole value in bits (per-character entropy times length); 0 means no minimum.
+	ForbidPlaceholders  bool     // If true, rejects placeholders (e.g. "changeme", "xxxx", "<your-api-key>") and common passwords from an embedded list.
+	RequiredCharClasses []string // Character classes that must all appear: "upper", "lower", "digit", "symbol". Optional.
+	MinCharClasses      int      // The minimum number of distinct character classes that must appear; 0 means no minimum.
+	Encoding            string   // If set, the value must decode as "base64", "base64url" or "hex".
+	MinDecodedBytes     int      // The minimum length in bytes of the decoded value; requires Encoding.
+}
+
+// Validate checks if the value associated with the given key meets the configured
+// strength requirements.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *SecretValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+	if p.MinDecodedBytes > 0 && p.Encoding == "" {
+		return true, fmt.Errorf("invalid configuration for key %q: MinDecodedBytes requires Encoding", key)
+	}
+
+	if value == "" {
+		return true, fmt.Errorf("value for key %q must not be empty", key)
+	}
+	if p.ForbidPlaceholders && isPlaceholder(value) {
+		return true, fmt.Errorf("value for key %q looks like a placeholder or common password", key)
+	}
+
+	length := len([]rune(value))
+	if p.MinLength > 0 && length < p.MinLength {
+		return true, fmt.Errorf("value for key %q must be at least %d characters long, got %d", key, p.MinLength, length)
+	}
+	if p.MinEntropyBits > 0 {
+		if bits := shannonEntropyBits(value); bits < p.MinEntropyBits {
+			return true, fmt.Errorf("value for key %q must have at least %.0f bits of entropy, got %.1f", key, p.MinEntropyBits, bits)
+		}
+	}
+
+	classes := charClasses(value)
+	for _, class := range p.RequiredCharClasses {
+		if !classes[strings.ToLower(class)] {
+			return true, fmt.Errorf("value for key %q must contain at least one %s character", key, strings.ToLower(class))
+		}
+	}
+	if p.MinCharClasses > 0 && len(classes) < p.MinCharClasses {
+		return true, fmt.Errorf("value for key %q must contain at least %d of the character classes upper, lower, digit and symbol, got %d", key, p.MinCharClasses, len(classes))
+	}
+
+	if p.Encoding != "" {
+		decoded, err := decodeValue(p.Encoding, value)
+		if err != nil {
+			// The decoding error is not included, as it may quote part of the secret.
+			return true, fmt.Errorf("value for key %q must be %s-encoded", key, p.Encoding)
+		}
+		if p.MinDecodedBytes > 0 && len(decoded) < p.MinDecodedBytes {
+			return true, fmt.Errorf("value for key %q must decode to at least %d bytes, got %d", key, p.MinDecodedBytes, len(decoded))
+		}
+	}
+
+	return true, nil
+}
+
+// isPlaceholder reports whether a value is a known placeholder or common password, a
+// single repeated character (e.g. "xxxx", "****"), a template such as "<api-key>" or
+// "${API_KEY}", or contains a marker such as "changeme" or "your_".
+//
+// Parameters:
+//   - value: The value to check.
+//
+// Returns:
+//   - bool: True if the value looks like a placeholder.
+func isPlaceholder(value string) bool {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	if commonSecrets[lower] {
+		return true
+	}
+
+	if lower == "" || strings.Trim(lower, string([]rune(lower)[0])) == "" {
+		return true
+	}
+
+	for _, wrap := range [][2]string{{"<", ">"}, {"{{", "}}"}, {"${", "}"}, {"[", "]"}, {"%", "%"}} {
+		if len(lower) > len(wrap[0])+len(wrap[1]) && strings.HasPrefix(lower, wrap[0]) && strings.HasSuffix(lower, wrap[1]) {
+			return true
+		}
+	}
+
+	for _, marker := range placeholderMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+
+	words := strings.FieldsFunc(lower, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		for _, placeholder := range placeholderWords {
+			if word == placeholder {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shannonEntropyBits returns the Shannon entropy of the value's character distribution
+// multiplied by its length, an estimate of the total information it carries in bits.
+//
+// Parameters:
+//   - value: The value to measure.
+//
+// Returns:
+//   - float64: The entropy in bits.
+func shannonEntropyBits(value string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range value {
+		counts[r]++
+		total++
+	}
+
+	var perChar float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		perChar -= p * math.Log2(p)
+	}
+	return perChar * float64(total)
+}
+
+// charClasses returns the set of character classes present in the value.
+//
+// Parameters:
+//   - value: The value to inspect.
+//
+// Returns:
+//   - map[string]bool: The classes present: "upper", "lower", "digit" and "symbol".
+func charClasses(value string) map[string]bool {
+	classes := make(map[string]bool, 4)
+	for _, r := range value {
+		switch {
+		case unicode.IsUpper(r):
+			classes["upper"] = true
+		case unicode.IsLower(r):
+			classes["lower"] = true
+		case unicode.IsDigit(r):
+			classes["digit"] = true
+		default:
+			classes["symbol"] = true
+		}
+	}
+	return classes
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *SecretValidationPlugin) Name() string {
+	return "SecretValidationPlugin"
+}
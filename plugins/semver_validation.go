@@ -0,0 +1,386 @@
+package plugins
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SemverValidationPlugin validates that the value of a specific environment variable key,
+// such as `SERVICE_VERSION`, is a Semantic Versioning 2.0.0 version and optionally that it
+// satisfies a range constraint such as ">=1.2.0 <2.0.0", "^1.4" or "~2.1.0 || >=3".
+//
+// Constraints are comparators (=, !=, >, >=, <, <=) joined by spaces or commas, which must
+// all hold, with alternatives separated by "||". Partial versions are expanded: ">1.2" means
+// ">=1.3.0", "<=1.2" means "<1.3.0" and "1.2" or "1.2.x" means ">=1.2.0 <1.3.0". Tilde
+// ranges allow patch updates ("~1.2.3" is ">=1.2.3 <1.3.0") and caret ranges allow updates
+// that do not change the left-most non-zero part ("^0.2.3" is ">=0.2.3 <0.3.0").
+// Pre-releases are compared by semver precedence.
+type SemverValidationPlugin struct {
+	Key              string // The key of the environment variable to validate.
+	Constraint       string // A range the version must satisfy, e.g., ">=1.2.0 <2.0.0". Optional.
+	AllowPrefix      bool   // If true, accepts a leading "v", e.g. "v1.2.3".
+	ForbidPrerelease bool   // If true, rejects pre-release versions such as "1.2.3-rc.1".
+}
+
+// semverRegex is the regular expression recommended by the Semantic Versioning 2.0.0 specification.
+var semverRegex = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// semver is a parsed semantic version. Build metadata is ignored for precedence.
+type semver struct {
+	major, minor, patch uint64   // The numeric version parts.
+	pre                 []string // The dot-separated pre-release identifiers, if any.
+}
+
+// semverComparator is a single comparison such as ">=1.2.0".
+type semverComparator struct {
+	op string // One of "=", "!=", ">", ">=", "<", "<=".
+	v  semver // The version to compare against.
+}
+
+// Validate checks if the value associated with the given key is a semantic version
+// that satisfies the configured constraint.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *SemverValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	version := value
+	if p.AllowPrefix {
+		version = strings.TrimPrefix(version, "v")
+	}
+	v, ok := parseSemver(version)
+	if !ok {
+		return true, fmt.Errorf("value for key %q must be a semantic version such as 1.2.3", key)
+	}
+	if p.ForbidPrerelease && len(v.pre) > 0 {
+		return true, fmt.Errorf("value for key %q must not be a pre-release version", key)
+	}
+
+	if p.Constraint != "" {
+		ranges, err := parseSemverConstraint(p.Constraint)
+		if err != nil {
+			return true, fmt.Errorf("invalid semver constraint %q for key %q: %v", p.Constraint, key, err)
+		}
+		if !semverSatisfies(v, ranges) {
+			return true, fmt.Errorf("value for key %q must satisfy %q, got %s", key, p.Constraint, version)
+		}
+	}
+
+	return true, nil
+}
+
+// parseSemver parses a full semantic version.
+//
+// Parameters:
+//   - value: The version, without a "v" prefix.
+//
+// Returns:
+//   - semver: The parsed version.
+//   - bool: False if the value is not a valid semantic version.
+func parseSemver(value string) (semver, bool) {
+	m := semverRegex.FindStringSubmatch(value)
+	if m == nil {
+		return semver{}, false
+	}
+	var v semver
+	var err error
+	if v.major, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+		return semver{}, false
+	}
+	if v.minor, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+		return semver{}, false
+	}
+	if v.patch, err = strconv.ParseUint(m[3], 10, 64); err != nil {
+		return semver{}, false
+	}
+	if m[4] != "" {
+		v.pre = strings.Split(m[4], ".")
+	}
+	return v, true
+}
+
+// compareSemver compares two versions by semver precedence.
+//
+// Parameters:
+//   - a: The first version.
+//   - b: The second version.
+//
+// Returns:
+//   - int: -1 if a < b, 0 if they have equal precedence, or 1 if a > b.
+func compareSemver(a, b semver) int {
+	for _, pair := range [][2]uint64{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// A version without pre-release identifiers has higher precedence.
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		x, y := a.pre[i], b.pre[i]
+		if x == y {
+			continue
+		}
+		xn, xErr := strconv.ParseUint(x, 10, 64)
+		yn, yErr := strconv.ParseUint(y, 10, 64)
+		switch {
+		case xErr == nil && yErr == nil:
+			if xn < yn {
+				return -1
+			}
+			return 1
+		case xErr == nil: // Numeric identifiers have lower precedence than alphanumeric ones.
+			return -1
+		case yErr == nil:
+			return 1
+		case x < y:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	switch {
+	case len(a.pre) < len(b.pre):
+		return -1
+	case len(a.pre) > len(b.pre):
+		return 1
+	}
+	return 0
+}
+
+// parseSemverConstraint parses a constraint into alternative ranges of comparators.
+//
+// Parameters:
+//   - constraint: The constraint, e.g. ">=1.2.0 <2.0.0 || ^3".
+//
+// Returns:
+//   - [][]semverComparator: The alternatives; each is satisfied if all its comparators hold.
+//   - error: An error if the constraint is malformed.
+func parseSemverConstraint(constraint string) ([][]semverComparator, error) {
+	alternatives := strings.Split(constraint, "||")
+	ranges := make([][]semverComparator, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		terms := strings.Fields(strings.ReplaceAll(alternative, ",", " "))
+		if len(terms) == 0 && len(alternatives) > 1 {
+			return nil, fmt.Errorf("empty alternative")
+		}
+		var comparators []semverComparator
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			// An operator may be separated from its version by spaces, as in ">= 1.2.0".
+			if isSemverOperator(term) {
+				if i+1 == len(terms) {
+					return nil, fmt.Errorf("missing version after %q", term)
+				}
+				i++
+				term += terms[i]
+			}
+			expanded, err := expandSemverTerm(term)
+			if err != nil {
+				return nil, err
+			}
+			comparators = append(comparators, expanded...)
+		}
+		ranges = append(ranges, comparators)
+	}
+	return ranges, nil
+}
+
+// semverOperators lists the operators a constraint term may start with, longest first.
+var semverOperators = []string{">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+// isSemverOperator reports whether term is an operator without a version.
+func isSemverOperator(term string) bool {
+	for _, op := range semverOperators {
+		if term == op {
+			return true
+		}
+	}
+	return false
+}
+
+// expandSemverTerm expands a single term such as "^1.2", ">=1.2.0" or "1.x" into comparators.
+//
+// Parameters:
+//   - term: The term.
+//
+// Returns:
+//   - []semverComparator: The equivalent comparators; empty if the term matches any version.
+//   - error: An error if the term is malformed.
+func expandSemverTerm(term string) ([]semverComparator, error) {
+	op := ""
+	for _, candidate := range semverOperators {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			break
+		}
+	}
+	version := strings.TrimPrefix(strings.TrimPrefix(term, op), "v")
+
+	// A full version (possibly with a pre-release) is compared directly.
+	if v, ok := parseSemver(version); ok {
+		switch op {
+		case "", "=":
+			return []semverComparator{{"=", v}}, nil
+		case "~":
+			return []semverComparator{{">=", v}, {"<", semver{major: v.major, minor: v.minor + 1}}}, nil
+		case "^":
+			return []semverComparator{{">=", v}, {"<", caretUpperBound(v.major, v.minor, v.patch, 3)}}, nil
+		default:
+			return []semverComparator{{op, v}}, nil
+		}
+	}
+
+	parts, err := parsePartialVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q in %q: %v", version, term, err)
+	}
+	n := len(parts)
+	if n == 0 {
+		if op == "<" || op == ">" || op == "!=" {
+			return nil, fmt.Errorf("%q matches no version", term)
+		}
+		return nil, nil // "*", "x" or ">=*" match any version.
+	}
+	for len(parts) < 3 {
+		parts = append(parts, 0)
+	}
+	lower := semver{major: parts[0], minor: parts[1], patch: parts[2]}
+	upper := semver{major: parts[0] + 1}
+	if n == 2 {
+		upper = semver{major: parts[0], minor: parts[1] + 1}
+	}
+
+	switch op {
+	case "", "=", "~":
+		return []semverComparator{{">=", lower}, {"<", upper}}, nil
+	case "^":
+		return []semverComparator{{">=", lower}, {"<", caretUpperBound(parts[0], parts[1], parts[2], n)}}, nil
+	case ">=":
+		return []semverComparator{{">=", lower}}, nil
+	case ">":
+		return []semverComparator{{">=", upper}}, nil
+	case "<":
+		return []semverComparator{{"<", lower}}, nil
+	case "<=":
+		return []semverComparator{{"<", upper}}, nil
+	}
+	return nil, fmt.Errorf("%q requires a full version", term)
+}
+
+// parsePartialVersion parses "1", "1.2", "1.x", "1.2.*" or "*" into its numeric parts.
+//
+// Parameters:
+//   - version: The partial version.
+//
+// Returns:
+//   - []uint64: The parts before the first wildcard.
+//   - error: An error if the version is malformed.
+func parsePartialVersion(version string) ([]uint64, error) {
+	var parts []uint64
+	fields := strings.Split(version, ".")
+	if len(fields) > 3 {
+		return nil, fmt.Errorf("too many parts")
+	}
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			for _, rest := range fields[i+1:] {
+				if rest != "x" && rest != "X" && rest != "*" {
+					return nil, fmt.Errorf("a wildcard must not be followed by a number")
+				}
+			}
+			break
+		}
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil || (len(field) > 1 && field[0] == '0') {
+			return nil, fmt.Errorf("%q is not a version number", field)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// caretUpperBound returns the exclusive upper bound of a caret range: the next version
+// that changes the left-most non-zero part among the n specified parts.
+func caretUpperBound(major, minor, patch uint64, n int) semver {
+	switch {
+	case major > 0 || n == 1:
+		return semver{major: major + 1}
+	case minor > 0 || n == 2:
+		return semver{minor: minor + 1}
+	default:
+		return semver{patch: patch + 1}
+	}
+}
+
+// semverSatisfies reports whether v satisfies any of the ranges.
+//
+// Parameters:
+//   - v: The version.
+//   - ranges: The alternatives parsed by parseSemverConstraint.
+//
+// Returns:
+//   - bool: True if all comparators of at least one alternative hold.
+func semverSatisfies(v semver, ranges [][]semverComparator) bool {
+	for _, comparators := range ranges {
+		satisfied := true
+		for _, c := range comparators {
+			cmp := compareSemver(v, c.v)
+			var ok bool
+			switch c.op {
+			case "=":
+				ok = cmp == 0
+			case "!=":
+				ok = cmp != 0
+			case ">":
+				ok = cmp > 0
+			case ">=":
+				ok = cmp >= 0
+			case "<":
+				ok = cmp < 0
+			case "<=":
+				ok = cmp <= 0
+			}
+			if !ok {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *SemverValidationPlugin) Name() string {
+	return "SemverValidationPlugin"
+}
@@ -0,0 +1,70 @@
+package plugins
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimestampValidationPlugin validates that the value of a specific environment variable key,
+// such as `MAINTENANCE_WINDOW_START`, is a timestamp or date in a given layout and,
+// optionally, that it falls within bounds. The layout uses the reference time of the
+// time package; it defaults to RFC 3339 (`2006-01-02T15:04:05Z07:00`). Use `2006-01-02`
+// for dates.
+type TimestampValidationPlugin struct {
+	Key           string         // The key of the environment variable to validate.
+	Layout        string         // The layout of the value, as accepted by time.Parse; defaults to time.RFC3339.
+	Location      *time.Location // The time zone of values whose layout has no zone offset; defaults to UTC.
+	Min           time.Time      // If set, the value must not be before this time.
+	Max           time.Time      // If set, the value must not be after this time.
+	RequireFuture bool           // If true, the value must be later than the time of validation.
+}
+
+// Validate checks if the value associated with the given key is a timestamp in the
+// configured layout and within the configured bounds.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *TimestampValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	layout := p.Layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	t, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be a timestamp in the layout %q, got %q", key, layout, value)
+	}
+
+	if !p.Min.IsZero() && t.Before(p.Min) {
+		return true, fmt.Errorf("value for key %q must not be before %s, got %s", key, p.Min.In(location).Format(layout), value)
+	}
+	if !p.Max.IsZero() && t.After(p.Max) {
+		return true, fmt.Errorf("value for key %q must not be after %s, got %s", key, p.Max.In(location).Format(layout), value)
+	}
+	if p.RequireFuture && !t.After(time.Now()) {
+		return true, fmt.Errorf("value for key %q must be in the future, got %s", key, value)
+	}
+
+	return true, nil
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *TimestampValidationPlugin) Name() string {
+	return "TimestampValidationPlugin"
+}
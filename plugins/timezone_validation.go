@@ -0,0 +1,61 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embeds the IANA time zone database so that zone names can be validated on
+	// systems without one installed, such as scratch or distroless containers.
+	_ "time/tzdata"
+)
+
+// TimezoneValidationPlugin validates that the value of a specific environment variable key,
+// such as `TZ`, is an IANA time zone name like "Europe/Berlin" or "UTC". Names are resolved
+// with time.LoadLocation, which falls back to the time zone database embedded in this
+// package when the system has none installed.
+type TimezoneValidationPlugin struct {
+	Key           string   // The key of the environment variable to validate.
+	RequireRegion bool     // If true, rejects legacy names without an Area/Location form, such as "EST" or "CET"; "UTC" is always accepted.
+	AllowedZones  []string // If set, the value must be one of these zone names. Optional.
+}
+
+// Validate checks if the value associated with the given key is a known IANA time zone name.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *TimezoneValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	// LoadLocation maps "" to UTC and "Local" to the host's zone; neither names a zone.
+	if value == "" || value == "Local" {
+		return true, fmt.Errorf("value for key %q must be an IANA time zone name, got %q", key, value)
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return true, fmt.Errorf("value for key %q must be an IANA time zone name, got %q", key, value)
+	}
+
+	if p.RequireRegion && value != "UTC" && !strings.Contains(value, "/") {
+		return true, fmt.Errorf("value for key %q must be a time zone in Area/Location form (e.g. \"America/New_York\"), got %q", key, value)
+	}
+	if len(p.AllowedZones) > 0 && !contains(p.AllowedZones, value) {
+		return true, fmt.Errorf("value for key %q must be one of %v, got %q", key, p.AllowedZones, value)
+	}
+
+	return true, nil
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *TimezoneValidationPlugin) Name() string {
+	return "TimezoneValidationPlugin"
+}
@@ -0,0 +1,354 @@
+package plugins
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// TLSValidationPlugin validates that the value of a specific environment variable key is a
+// PEM-encoded TLS certificate or private key, given inline or as the path of a PEM file
+// (e.g. `TLS_CERT_PATH`, `TLS_KEY_PATH`). It checks certificate validity dates (warning when
+// a certificate expires soon), key type and size, that a certificate and its private key
+// stored under two keys belong together, and optionally the certificate chain against a CA bundle.
+type TLSValidationPlugin struct {
+	Key               string   // The key of the environment variable to validate.
+	Kind              string   // The expected content: "certificate" or "key". Optional; detected from the PEM data by default.
+	ExpiryWarningDays int      // If set, a certificate expiring within this many days is reported as a warning.
+	AllowedKeyTypes   []string // Allowed key types: "RSA", "ECDSA", "Ed25519". Optional.
+	MinRSABits        int      // The minimum RSA modulus size in bits, e.g., 2048; 0 means no minimum.
+	MinECDSABits      int      // The minimum ECDSA curve size in bits, e.g., 256; 0 means no minimum.
+	MatchingKey       string   // The key holding the counterpart: the private key for a certificate, or the certificate for a key. Optional.
+	CABundle          string   // The path of a PEM bundle of trusted CA certificates to verify the chain against. Optional.
+	BaseDir           string   // The directory relative paths are resolved against; defaults to the `.env` file's directory. Optional.
+}
+
+// tlsMaterial holds the certificates and private key decoded from a PEM value.
+type tlsMaterial struct {
+	certs []*x509.Certificate // The certificates, leaf first.
+	key   crypto.Signer       // The private key, if any.
+}
+
+// Validate checks if the value associated with the given key is a valid certificate or
+// private key. The cross-key check needs the other values of the `.env` file and only
+// runs when validating a file.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid, a *Warning if the certificate expires soon, or nil if it passes validation.
+func (p *TLSValidationPlugin) Validate(key, value string) (bool, error) {
+	return p.ValidateContext(context.Background(), key, value)
+}
+
+// ValidateContext validates the value like Validate, using the context to resolve relative
+// paths against the `.env` file's directory and to look up MatchingKey.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or the context is done, a *Warning if the
+//     certificate expires soon, or nil if it passes validation.
+func (p *TLSValidationPlugin) ValidateContext(ctx context.Context, key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+	if err := ctx.Err(); err != nil {
+		return true, err
+	}
+
+	material, err := p.load(ctx, value)
+	if err != nil {
+		return true, fmt.Errorf("value for key %q must be a PEM certificate or private key: %v", key, err)
+	}
+
+	kind := strings.ToLower(p.Kind)
+	if kind == "" {
+		kind = "certificate"
+		if len(material.certs) == 0 {
+			kind = "key"
+		}
+	}
+
+	switch kind {
+	case "certificate", "cert":
+		if len(material.certs) == 0 {
+			return true, fmt.Errorf("value for key %q must contain a PEM certificate", key)
+		}
+		return true, p.validateCertificate(ctx, key, material.certs)
+	case "key":
+		if material.key == nil {
+			return true, fmt.Errorf("value for key %q must contain a PEM private key", key)
+		}
+		return true, p.validateKey(ctx, key, material.key)
+	}
+	return true, fmt.Errorf("unknown Kind %q for key %q; use \"certificate\" or \"key\"", p.Kind, key)
+}
+
+// validateCertificate checks a certificate chain.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key of the environment variable being validated.
+//   - certs: The certificates, leaf first.
+//
+// Returns:
+//   - error: An error if a check fails, a *Warning if the certificate expires soon, or nil.
+func (p *TLSValidationPlugin) validateCertificate(ctx context.Context, key string, certs []*x509.Certificate) error {
+	leaf := certs[0]
+	now := time.Now()
+
+	if now.Before(leaf.NotBefore) {
+		return fmt.Errorf("certificate for key %q is not valid until %s", key, leaf.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("certificate for key %q expired on %s", key, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if err := p.checkPublicKey(key, leaf.PublicKey); err != nil {
+		return err
+	}
+
+	if p.CABundle != "" {
+		if err := p.verifyChain(ctx, key, certs); err != nil {
+			return err
+		}
+	}
+
+	if p.MatchingKey != "" {
+		if counterpart, ok := EnvValueFromContext(ctx, p.MatchingKey); ok {
+			material, err := p.load(ctx, counterpart)
+			if err != nil || material.key == nil {
+				return fmt.Errorf("certificate for key %q cannot be checked against key %q: it does not contain a PEM private key", key, p.MatchingKey)
+			}
+			if !publicKeysEqual(leaf.PublicKey, material.key.Public()) {
+				return fmt.Errorf("certificate for key %q does not match the private key in %q", key, p.MatchingKey)
+			}
+		}
+	}
+
+	if p.ExpiryWarningDays > 0 {
+		if remaining := leaf.NotAfter.Sub(now); remaining < time.Duration(p.ExpiryWarningDays)*24*time.Hour {
+			return Warnf("certificate for key %q expires in %d days (on %s)", key, int(remaining.Hours()/24), leaf.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// validateKey checks a private key.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The key of the environment variable being validated.
+//   - privateKey: The private key.
+//
+// Returns:
+//   - error: An error if a check fails, or nil.
+func (p *TLSValidationPlugin) validateKey(ctx context.Context, key string, privateKey crypto.Signer) error {
+	if err := p.checkPublicKey(key, privateKey.Public()); err != nil {
+		return err
+	}
+
+	if p.MatchingKey != "" {
+		if counterpart, ok := EnvValueFromContext(ctx, p.MatchingKey); ok {
+			material, err := p.load(ctx, counterpart)
+			if err != nil || len(material.certs) == 0 {
+				return fmt.Errorf("private key for key %q cannot be checked against key %q: it does not contain a PEM certificate", key, p.MatchingKey)
+			}
+			if !publicKeysEqual(material.certs[0].PublicKey, privateKey.Public()) {
+				return fmt.Errorf("private key for key %q does not match the certificate in %q", key, p.MatchingKey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPublicKey checks the key type and size.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - pub: The public key of the certificate or private key.
+//
+// Returns:
+//   - error: An error if the key type is not allowed or the key is too small.
+func (p *TLSValidationPlugin) checkPublicKey(key string, pub crypto.PublicKey) error {
+	keyType, bits := describePublicKey(pub)
+
+	if len(p.AllowedKeyTypes) > 0 {
+		allowed := false
+		for _, t := range p.AllowedKeyTypes {
+			if strings.EqualFold(t, keyType) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("key type for key %q must be one of %v, got %s", key, p.AllowedKeyTypes, keyType)
+		}
+	}
+
+	if keyType == "RSA" && p.MinRSABits > 0 && bits < p.MinRSABits {
+		return fmt.Errorf("RSA key for key %q must be at least %d bits, got %d", key, p.MinRSABits, bits)
+	}
+	if keyType == "ECDSA" && p.MinECDSABits > 0 && bits < p.MinECDSABits {
+		return fmt.Errorf("ECDSA key for key %q must be at least %d bits, got %d", key, p.MinECDSABits, bits)
+	}
+	return nil
+}
+
+// verifyChain verifies the certificate chain against the CA bundle. Certificates after the
+// leaf are used as intermediates.
+//
+// Parameters:
+//   - ctx: The context, used to resolve a relative CA bundle path.
+//   - key: The key of the environment variable being validated.
+//   - certs: The certificates, leaf first.
+//
+// Returns:
+//   - error: An error if the bundle cannot be read or the chain does not verify.
+func (p *TLSValidationPlugin) verifyChain(ctx context.Context, key string, certs []*x509.Certificate) error {
+	bundle, err := os.ReadFile(resolvePath(ctx, p.BaseDir, p.CABundle))
+	if err != nil {
+		return fmt.Errorf("failed to read CA bundle for key %q: %v", key, err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("CA bundle for key %q contains no certificates", key)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	opts := x509.VerifyOptions{Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return fmt.Errorf("certificate for key %q does not verify against the CA bundle: %v", key, err)
+	}
+	return nil
+}
+
+// load decodes the PEM data in value, reading it from a file unless the value is inline PEM.
+//
+// Parameters:
+//   - ctx: The context, used to resolve relative paths.
+//   - value: Inline PEM data or the path of a PEM file.
+//
+// Returns:
+//   - *tlsMaterial: The decoded certificates and private key.
+//   - error: An error if the file cannot be read or the data is not valid PEM.
+func (p *TLSValidationPlugin) load(ctx context.Context, value string) (*tlsMaterial, error) {
+	data := []byte(value)
+	if !strings.Contains(value, "-----BEGIN ") {
+		var err error
+		if data, err = os.ReadFile(resolvePath(ctx, p.BaseDir, value)); err != nil {
+			return nil, err
+		}
+	}
+
+	material := &tlsMaterial{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate: %v", err)
+			}
+			material.certs = append(material.certs, cert)
+		case block.Type == "ENCRYPTED PRIVATE KEY":
+			return nil, fmt.Errorf("encrypted private keys are not supported")
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			privateKey, err := parsePrivateKey(block)
+			if err != nil {
+				return nil, fmt.Errorf("invalid private key: %v", err)
+			}
+			material.key = privateKey
+		}
+	}
+
+	if len(material.certs) == 0 && material.key == nil {
+		return nil, fmt.Errorf("no PEM certificate or private key found")
+	}
+	return material, nil
+}
+
+// parsePrivateKey parses a PKCS #8, PKCS #1 (RSA) or SEC 1 (EC) private key.
+//
+// Parameters:
+//   - block: The PEM block.
+//
+// Returns:
+//   - crypto.Signer: The private key.
+//   - error: An error if the key cannot be parsed.
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// describePublicKey returns the type name and size in bits of a public key.
+//
+// Parameters:
+//   - pub: The public key.
+//
+// Returns:
+//   - string: "RSA", "ECDSA", "Ed25519" or the Go type for other keys.
+//   - int: The RSA modulus or ECDSA curve size in bits, or 256 for Ed25519.
+func describePublicKey(pub crypto.PublicKey) (string, int) {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return "RSA", k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "Ed25519", 256
+	}
+	return fmt.Sprintf("%T", pub), 0
+}
+
+// publicKeysEqual reports whether two public keys are the same key.
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	equaler, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && equaler.Equal(b)
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *TLSValidationPlugin) Name() string {
+	return "TLSValidationPlugin"
+}
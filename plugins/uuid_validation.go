@@ -0,0 +1,115 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UUIDValidationPlugin validates that the value of a specific environment variable key,
+// such as `TENANT_ID`, is a UUID in the canonical 8-4-4-4-12 hexadecimal form. It can
+// restrict the UUID version (e.g. 4 or 7). The special nil and max UUIDs of RFC 9562 have
+// no version and are rejected unless allowed.
+type UUIDValidationPlugin struct {
+	Key              string // The key of the environment variable to validate.
+	AllowedVersions  []int  // Allowed UUID versions (1-8). Optional; any version is accepted by default.
+	AllowNil         bool   // If true, accepts the nil UUID "00000000-0000-0000-0000-000000000000".
+	AllowMax         bool   // If true, accepts the max UUID "ffffffff-ffff-ffff-ffff-ffffffffffff".
+	RequireLowercase bool   // If true, hexadecimal digits must be lowercase, as RFC 9562 recommends for output.
+}
+
+// Validate checks if the value associated with the given key is a UUID of an allowed version.
+//
+// Parameters:
+//   - key: The key of the environment variable being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether this plugin handled the validation.
+//   - error: An error if the value is invalid or nil if it passes validation.
+func (p *UUIDValidationPlugin) Validate(key, value string) (bool, error) {
+	if key != p.Key {
+		return false, nil // Plugin does not handle this key.
+	}
+
+	if !isCanonicalUUID(value) {
+		return true, fmt.Errorf("value for key %q must be a UUID in the form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", key)
+	}
+	if p.RequireLowercase && strings.ToLower(value) != value {
+		return true, fmt.Errorf("value for key %q must be a lowercase UUID", key)
+	}
+
+	if value == "00000000-0000-0000-0000-000000000000" {
+		if !p.AllowNil {
+			return true, fmt.Errorf("value for key %q must not be the nil UUID", key)
+		}
+		return true, nil
+	}
+	if strings.EqualFold(value, "ffffffff-ffff-ffff-ffff-ffffffffffff") {
+		if !p.AllowMax {
+			return true, fmt.Errorf("value for key %q must not be the max UUID", key)
+		}
+		return true, nil
+	}
+
+	// The version is the high nibble of byte 6; RFC 9562 UUIDs have the variant bits 10xx in byte 8.
+	version := int(hexDigit(value[14]))
+	if variant := hexDigit(value[19]); variant&0xc != 0x8 {
+		return true, fmt.Errorf("value for key %q must be an RFC 9562 UUID (variant 10xx)", key)
+	}
+	if len(p.AllowedVersions) > 0 {
+		allowed := false
+		for _, v := range p.AllowedVersions {
+			if v == version {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return true, fmt.Errorf("value for key %q must be a UUID of version %v, got version %d", key, p.AllowedVersions, version)
+		}
+	}
+
+	return true, nil
+}
+
+// isCanonicalUUID reports whether value has the form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+// with hexadecimal digits of either case.
+func isCanonicalUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if value[i] != '-' {
+				return false
+			}
+		default:
+			if hexDigit(value[i]) > 0xf {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hexDigit returns the value of a hexadecimal digit, or 0xff if c is not one.
+func hexDigit(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10
+	}
+	return 0xff
+}
+
+// Name returns the name of the plugin.
+//
+// Returns:
+//   - string: The name of the plugin.
+func (p *UUIDValidationPlugin) Name() string {
+	return "UUIDValidationPlugin"
+}
@@ -1,5 +1,11 @@
 package plugins
 
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
 // ValidationPlugin defines the interface that all validation plugins must implement.
 // Validation plugins are used to enforce specific validation rules for key-value pairs
 // in environment variable files.
@@ -21,3 +27,227 @@ type ValidationPlugin interface {
 	//   - string: The name of the plugin.
 	Name() string
 }
+
+// FixerPlugin is an optional interface for validation plugins that can rewrite a value
+// into its canonical form. It is used by the Validator's fix mode to correct mechanical
+// problems, such as casing or non-canonical representations, without changing meaning.
+type FixerPlugin interface {
+	ValidationPlugin
+
+	// Fix returns the canonical form of the value for the given key.
+	//
+	// Parameters:
+	//   - key: The environment variable key being fixed.
+	//   - value: The current value of the environment variable.
+	//
+	// Returns:
+	//   - string: The fixed value, or the original value if no fix applies.
+	//   - bool: True if the value was changed.
+	Fix(key, value string) (string, bool)
+}
+
+// ContextValidationPlugin is implemented by validation plugins that may perform slow work,
+// such as filesystem or network checks, and should stop when the validation is canceled
+// or times out.
+type ContextValidationPlugin interface {
+	ValidationPlugin
+
+	// ValidateContext checks whether the provided key-value pair conforms to the plugin's
+	// validation rules, returning promptly once the context is done.
+	//
+	// Parameters:
+	//   - ctx: The context for the validation; its cancellation or deadline should stop the check.
+	//   - key: The environment variable key being validated.
+	//   - value: The value of the environment variable to validate.
+	//
+	// Returns:
+	//   - bool: Indicates whether the plugin handled the validation for the given key.
+	//   - error: An error if the value does not satisfy the validation rules or the context is done.
+	ValidateContext(ctx context.Context, key, value string) (bool, error)
+}
+
+// DescriberPlugin is an optional interface for validation plugins that can summarize what
+// a valid value means, such as when a cron schedule next runs. Descriptions are logged
+// alongside the validating plugin when the Validator runs in verbose mode.
+type DescriberPlugin interface {
+	ValidationPlugin
+
+	// Describe returns a short, human-readable summary of the value for the given key.
+	//
+	// Parameters:
+	//   - key: The environment variable key being described.
+	//   - value: The value of the environment variable, which has passed validation.
+	//
+	// Returns:
+	//   - string: The description.
+	//   - bool: True if the plugin handles the key and has a description for the value.
+	Describe(key, value string) (string, bool)
+}
+
+// WithContext adapts a ValidationPlugin to the ContextValidationPlugin interface.
+// Plugins that already implement ContextValidationPlugin are returned unchanged. For other
+// plugins, Validate runs in a separate goroutine so that the caller stops waiting as soon
+// as the context is done; the plugin itself keeps running until Validate returns.
+//
+// Parameters:
+//   - plugin: The plugin to adapt.
+//
+// Returns:
+//   - ContextValidationPlugin: A context-aware plugin.
+func WithContext(plugin ValidationPlugin) ContextValidationPlugin {
+	if p, ok := plugin.(ContextValidationPlugin); ok {
+		return p
+	}
+	return contextAdapter{plugin}
+}
+
+// contextAdapter wraps a ValidationPlugin that does not support contexts.
+type contextAdapter struct {
+	ValidationPlugin
+}
+
+// ValidateContext runs the wrapped plugin's Validate method, returning early if the context is done.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - key: The environment variable key being validated.
+//   - value: The value of the environment variable to validate.
+//
+// Returns:
+//   - bool: Indicates whether the plugin handled the validation for the given key.
+//   - error: The plugin's error, or the context's error if it is done first.
+func (a contextAdapter) ValidateContext(ctx context.Context, key, value string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if ctx.Done() == nil {
+		return a.Validate(key, value)
+	}
+
+	type result struct {
+		handled bool
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		handled, err := a.Validate(key, value)
+		done <- result{handled, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.handled, r.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// envFileKey is the context key under which the path of the `.env` file being validated is stored.
+type envFileKey struct{}
+
+// ContextWithEnvFile returns a copy of ctx that carries the path of the `.env` file being
+// validated. The Validator sets it before running context-aware plugins, so that they can,
+// for example, resolve relative paths against the file's directory.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - path: The path of the `.env` file.
+//
+// Returns:
+//   - context.Context: The derived context.
+func ContextWithEnvFile(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, envFileKey{}, path)
+}
+
+// EnvFileFromContext returns the path of the `.env` file being validated, if the context carries one.
+//
+// Parameters:
+//   - ctx: The context passed to ValidateContext.
+//
+// Returns:
+//   - string: The path of the `.env` file.
+//   - bool: True if the context carries a path.
+func EnvFileFromContext(ctx context.Context) (string, bool) {
+	path, ok := ctx.Value(envFileKey{}).(string)
+	return path, ok
+}
+
+// envValuesKey is the context key under which the values of the `.env` file being validated are stored.
+type envValuesKey struct{}
+
+// ContextWithEnvValues returns a copy of ctx that carries the values of the `.env` file
+// being validated. The Validator sets it before running context-aware plugins, so that they
+// can check one key against another (e.g. a certificate against its private key).
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - values: The values of the `.env` file, keyed by key. The map must not be modified.
+//
+// Returns:
+//   - context.Context: The derived context.
+func ContextWithEnvValues(ctx context.Context, values map[string]string) context.Context {
+	return context.WithValue(ctx, envValuesKey{}, values)
+}
+
+// EnvValueFromContext returns the value of another key in the `.env` file being validated,
+// if the context carries the file's values.
+//
+// Parameters:
+//   - ctx: The context passed to ValidateContext.
+//   - key: The key to look up.
+//
+// Returns:
+//   - string: The key's value.
+//   - bool: True if the context carries the values and the key is set.
+func EnvValueFromContext(ctx context.Context, key string) (string, bool) {
+	values, _ := ctx.Value(envValuesKey{}).(map[string]string)
+	value, ok := values[key]
+	return value, ok
+}
+
+// Warning is an error that reports a problem without failing validation, such as a
+// certificate that expires soon. Plugins return it (usually via Warnf) from Validate;
+// the Validator logs it as a warning and continues.
+type Warning struct {
+	Err error // The underlying problem.
+}
+
+// Error returns the underlying problem's message.
+//
+// Returns:
+//   - string: The warning message.
+func (w *Warning) Error() string {
+	return w.Err.Error()
+}
+
+// Unwrap returns the underlying problem.
+//
+// Returns:
+//   - error: The underlying problem.
+func (w *Warning) Unwrap() error {
+	return w.Err
+}
+
+// Warnf formats a warning according to a format specifier.
+//
+// Parameters:
+//   - format: The format string, as for fmt.Errorf.
+//   - args: The format arguments.
+//
+// Returns:
+//   - error: A *Warning wrapping the formatted error.
+func Warnf(format string, args ...any) error {
+	return &Warning{Err: fmt.Errorf(format, args...)}
+}
+
+// IsWarning reports whether err is, or wraps, a *Warning.
+//
+// Parameters:
+//   - err: The error to check.
+//
+// Returns:
+//   - bool: True if the error is a warning.
+func IsWarning(err error) bool {
+	var w *Warning
+	return errors.As(err, &w)
+}
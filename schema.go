@@ -0,0 +1,117 @@
+package validot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Schema declares the keys of a `.env` file in a JSON schema file, so that required keys,
+// optional keys and their defaults can be kept next to the `.env` file rather than in Go code:
+//
+//	{
+//	  "keys": {
+//	    "DB_HOST":   {"required": true},
+//	    "DB_PORT":   {"default": "5432"},
+//	    "CACHE_DIR": {}
+//	  }
+//	}
+//
+// Keys that are not required are optional keys. Use Apply and RequiredKeys to configure a Validator.
+type Schema struct {
+	Keys map[string]SchemaKey `json:"keys"` // The declared keys.
+}
+
+// SchemaKey declares a single key in a Schema.
+type SchemaKey struct {
+	Required bool    `json:"required"` // If true, the key must be set in the `.env` file.
+	Default  *string `json:"default"`  // The value used when the `.env` file does not set the key, like Config.Defaults; nil means no default.
+}
+
+// LoadSchema reads a Schema from a JSON file.
+//
+// Parameters:
+//   - filePath: The path to the schema file.
+//
+// Returns:
+//   - *Schema: The schema.
+//   - error: An error if the file could not be read, is not a valid schema, or declares a required key with a default.
+func LoadSchema(filePath string) (*Schema, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var schema Schema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", filePath, err)
+	}
+
+	for _, key := range schema.sortedKeys() {
+		// Defaults do not satisfy required keys, so a default for a required key would never be used.
+		if declared := schema.Keys[key]; declared.Required && declared.Default != nil {
+			return nil, fmt.Errorf("invalid schema file %s: key %q is required and cannot have a default", filePath, key)
+		}
+	}
+	return &schema, nil
+}
+
+// RequiredKeys returns the keys the schema declares as required, for NewValidator.
+//
+// Returns:
+//   - []string: The required keys, in sorted order.
+func (s *Schema) RequiredKeys() []string {
+	var keys []string
+	for _, key := range s.sortedKeys() {
+		if s.Keys[key].Required {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Apply returns a copy of the configuration with the schema's defaults added to
+// Config.Defaults and its keys that are not required added to Config.OptionalKeys.
+// Defaults already in the configuration take precedence over those in the schema.
+//
+// Parameters:
+//   - config: The configuration to extend.
+//
+// Returns:
+//   - Config: The extended configuration.
+func (s *Schema) Apply(config Config) Config {
+	defaults := make(map[string]string, len(config.Defaults)+len(s.Keys))
+	optional := append([]string(nil), config.OptionalKeys...)
+	for _, key := range s.sortedKeys() {
+		declared := s.Keys[key]
+		if declared.Default != nil {
+			defaults[key] = *declared.Default
+		}
+		if !declared.Required {
+			optional = append(optional, key)
+		}
+	}
+	for key, value := range config.Defaults {
+		defaults[key] = value
+	}
+
+	if len(defaults) > 0 {
+		config.Defaults = defaults
+	}
+	config.OptionalKeys = optional
+	return config
+}
+
+// sortedKeys returns the declared keys in sorted order.
+func (s *Schema) sortedKeys() []string {
+	keys := make([]string, 0, len(s.Keys))
+	for key := range s.Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
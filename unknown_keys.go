@@ -0,0 +1,149 @@
+package validot
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mwiater/go-validot/internal/suggest"
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// unknownKeyProblem returns the problem with a key that is neither declared nor handled by
+// a plugin, as configured by Config.UnknownKeys, with a suggestion if a known key is close.
+//
+// Parameters:
+//   - key: The key to check.
+//   - result: The outcome of running the plugins against the key.
+//
+// Returns:
+//   - error: A warning (see plugins.IsWarning) or an error, or nil if the key is known or unknown keys are allowed.
+func (v *Validator) unknownKeyProblem(key string, result keyResult) error {
+	if v.config.UnknownKeys == UnknownKeysAllow || v.isKnownKey(key, result) {
+		return nil
+	}
+
+	message := fmt.Sprintf("unknown key %q", key)
+	if suggestion, ok := suggest.Closest(key, v.knownKeys()); ok {
+		message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+	}
+	if v.config.UnknownKeys == UnknownKeysWarn {
+		return plugins.Warnf("%s", message)
+	}
+	return errors.New(message)
+}
+
+// isKnownKey reports whether a key is required, optional, defaulted, deprecated, the
+// replacement of a deprecated key, ignored by prefix, or handled by a plugin.
+//
+// Parameters:
+//   - key: The key to check.
+//   - result: The outcome of running the plugins against the key.
+//
+// Returns:
+//   - bool: True if the key is known.
+func (v *Validator) isKnownKey(key string, result keyResult) bool {
+	if _, ok := v.requiredKeys[key]; ok {
+		return true
+	}
+	if _, ok := v.config.Defaults[key]; ok {
+		return true
+	}
+	if _, ok := v.config.DeprecatedKeys[key]; ok {
+		return true
+	}
+	for _, deprecated := range v.config.DeprecatedKeys {
+		if deprecated.Replacement == key {
+			return true
+		}
+	}
+	for _, optional := range v.config.OptionalKeys {
+		if optional == key {
+			return true
+		}
+	}
+	for _, prefix := range v.config.IgnoredKeyPrefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for _, outcome := range result.outcomes {
+		if outcome.handled {
+			return true
+		}
+	}
+	return false
+}
+
+// knownKeys returns the keys suggested for unknown keys: the declared keys followed by the
+// keys of plugins that have a `Key` field. Deprecated keys are not suggested.
+//
+// Returns:
+//   - []string: The known keys, without duplicates.
+func (v *Validator) knownKeys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	add := func(key string) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range v.requiredOrder {
+		add(key)
+	}
+	for _, key := range v.config.OptionalKeys {
+		add(key)
+	}
+
+	var declared []string
+	for key := range v.config.Defaults {
+		declared = append(declared, key)
+	}
+	for _, deprecated := range v.config.DeprecatedKeys {
+		declared = append(declared, deprecated.Replacement)
+	}
+	sort.Strings(declared)
+	for _, key := range declared {
+		add(key)
+	}
+
+	for _, plugin := range v.plugins {
+		if key, ok := pluginKey(plugin); ok {
+			add(key)
+		}
+	}
+	return keys
+}
+
+// pluginKey returns the value of a plugin's exported `Key` string field, the convention
+// followed by the plugins in the plugins package.
+//
+// Parameters:
+//   - plugin: The plugin to inspect.
+//
+// Returns:
+//   - string: The key the plugin validates.
+//   - bool: False if the plugin has no non-empty `Key` field.
+func pluginKey(plugin plugins.ValidationPlugin) (string, bool) {
+	rv := reflect.ValueOf(plugin)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "", false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	field, ok := rv.Type().FieldByName("Key")
+	if !ok || !field.IsExported() || field.Type.Kind() != reflect.String {
+		return "", false
+	}
+	key := rv.FieldByIndex(field.Index).String()
+	return key, key != ""
+}
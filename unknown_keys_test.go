@@ -0,0 +1,84 @@
+// unknown_keys_test.go
+package validot
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const unknownKeysEnv = "API_URL=https://api.myapp.com/v1/\n" +
+	"DB_HOST=db.internal\n" +
+	"DB_HSOT=db.internal\n" +
+	"CACHE_SIZE=256\n" +
+	"OTEL_SERVICE_NAME=api\n" +
+	"FEATURE_FLAGS=a,b\n" +
+	"REDIS_HOST=cache.internal\n" +
+	"ENVIRONMENT=PRODUCTION\n"
+
+func newUnknownKeysValidator(logOutput io.Writer, mode UnknownKeyMode) *Validator {
+	logger := logrus.New()
+	logger.SetOutput(logOutput)
+
+	return NewValidator(Config{
+		Logger:             logger,
+		UnknownKeys:        mode,
+		OptionalKeys:       []string{"CACHE_SIZE"},
+		IgnoredKeyPrefixes: []string{"OTEL_"},
+		Defaults:           map[string]string{"LOG_LEVEL": "info"},
+		DeprecatedKeys:     map[string]DeprecatedKey{"REDIS_HOST": {Replacement: "CACHE_HOST"}},
+		Plugins:            []plugins.ValidationPlugin{&plugins.ListValidationPlugin{Key: "FEATURE_FLAGS"}},
+	}, []string{"DB_HOST"})
+}
+
+func TestValidateDotEnv_UnknownKeysAllowedByDefault(t *testing.T) {
+	envFilePath := createTempEnvFile(t, unknownKeysEnv)
+	validator := newUnknownKeysValidator(io.Discard, UnknownKeysAllow)
+
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+}
+
+func TestValidateDotEnv_UnknownKeysError(t *testing.T) {
+	envFilePath := createTempEnvFile(t, unknownKeysEnv)
+	validator := newUnknownKeysValidator(io.Discard, UnknownKeysError)
+
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Equal(t, `unknown key "DB_HSOT" (did you mean "DB_HOST"?)`, err.Error())
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	var messages []string
+	for _, finding := range findings {
+		messages = append(messages, finding.String())
+	}
+	// Required, optional, defaulted, deprecated, ignored and plugin-handled keys are known.
+	assert.Equal(t, []string{
+		envFilePath + `:3: unknown key "DB_HSOT" (did you mean "DB_HOST"?)`,
+		envFilePath + `:7: warning: key "REDIS_HOST" is deprecated; use "CACHE_HOST" instead`,
+	}, messages)
+}
+
+func TestValidateDotEnv_UnknownKeysWarn(t *testing.T) {
+	envFilePath := createTempEnvFile(t, unknownKeysEnv+"TOTALLY_UNRELATED=1\nLOG_LEVLE=debug\nENVIRONMNET=STAGING\n")
+
+	var logBuffer bytes.Buffer
+	validator := newUnknownKeysValidator(&logBuffer, UnknownKeysWarn)
+
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+	logs := logBuffer.String()
+	assert.Contains(t, logs, `Unknown key warning for key DB_HSOT: unknown key \"DB_HSOT\" (did you mean \"DB_HOST\"?)`)
+	assert.Contains(t, logs, `unknown key \"TOTALLY_UNRELATED\""`, "Unrelated keys get no suggestion")
+	assert.Contains(t, logs, `unknown key \"LOG_LEVLE\" (did you mean \"LOG_LEVEL\"?)`, "Defaulted keys are suggested")
+	assert.Contains(t, logs, `unknown key \"ENVIRONMNET\" (did you mean \"ENVIRONMENT\"?)`, "Keys of plugins are suggested")
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	for _, finding := range findings {
+		assert.Equal(t, SeverityWarning, finding.Severity, finding.String())
+	}
+}
@@ -1,9 +1,12 @@
 package validot
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
 
-	"github.com/joho/godotenv"
 	"github.com/mwiater/go-validot/plugins"
 	"github.com/sirupsen/logrus"
 )
@@ -11,9 +14,10 @@ import (
 // Validator is responsible for validating `.env` files based on the provided configuration.
 // It includes required keys, configuration options, and validation plugins.
 type Validator struct {
-	config       Config                     // Configuration settings for the Validator.
-	requiredKeys map[string]bool            // A map of keys that are required in the `.env` file.
-	plugins      []plugins.ValidationPlugin // List of validation plugins to apply to the `.env` file.
+	config        Config                     // Configuration settings for the Validator.
+	requiredKeys  map[string]bool            // A map of keys that are required in the `.env` file.
+	requiredOrder []string                   // The required keys in the order they were declared.
+	plugins       []plugins.ValidationPlugin // List of validation plugins to apply to the `.env` file.
 }
 
 // NewValidator initializes and returns a new Validator instance.
@@ -26,7 +30,11 @@ type Validator struct {
 //   - *Validator: A pointer to a newly created Validator instance.
 func NewValidator(config Config, requiredKeys []string) *Validator {
 	reqKeys := make(map[string]bool)
+	var reqOrder []string
 	for _, key := range requiredKeys {
+		if _, exists := reqKeys[key]; !exists {
+			reqOrder = append(reqOrder, key)
+		}
 		reqKeys[key] = false
 	}
 
@@ -34,9 +42,10 @@ func NewValidator(config Config, requiredKeys []string) *Validator {
 	allPlugins := append(builtInPlugins, config.Plugins...)
 
 	return &Validator{
-		config:       config,
-		requiredKeys: reqKeys,
-		plugins:      allPlugins,
+		config:        config,
+		requiredKeys:  reqKeys,
+		requiredOrder: reqOrder,
+		plugins:       allPlugins,
 	}
 }
 
@@ -85,37 +94,114 @@ func loadBuiltInPlugins() []plugins.ValidationPlugin {
 // Returns:
 //   - error: An error if validation fails, or nil if the `.env` file is valid.
 func (v *Validator) ValidateDotEnv(filePath string) error {
-	if v.config.Logger == nil {
-		v.config.Logger = logrus.New()
-		if v.config.Verbose {
-			v.config.Logger.SetLevel(logrus.DebugLevel)
-		} else {
-			v.config.Logger.SetLevel(logrus.InfoLevel)
-		}
-	}
+	return v.ValidateDotEnvContext(context.Background(), filePath)
+}
+
+// ValidateDotEnvContext validates the `.env` file at the specified path, stopping promptly
+// when the context is canceled or its deadline passes. Plugins implementing
+// plugins.ContextValidationPlugin receive the context (limited by any configured plugin
+// timeout); other plugins are adapted with plugins.WithContext.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - filePath: The path to the `.env` file to validate.
+//
+// Returns:
+//   - error: An error if validation fails, a *CanceledError listing the keys that were not
+//     checked if the context is done, or nil if the `.env` file is valid.
+func (v *Validator) ValidateDotEnvContext(ctx context.Context, filePath string) error {
+	_, err := v.validate(ctx, filePath)
+	return err
+}
+
+// validate implements ValidateDotEnvContext, returning the effective values of a valid file.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - filePath: The path to the `.env` file to validate.
+//
+// Returns:
+//   - *Values: The values of the `.env` file after interpolation, including defaults, if it is valid.
+//   - error: An error if validation fails, as returned by ValidateDotEnvContext.
+func (v *Validator) validate(ctx context.Context, filePath string) (*Values, error) {
+	v.ensureLogger()
 
 	if v.config.Verbose {
 		v.config.Logger.Infof("Validator Configuration:")
 		v.config.Logger.Infof("  RequireQuotes: %v", v.config.RequireQuotes)
 		v.config.Logger.Infof("  Verbose: %v", v.config.Verbose)
+		v.config.Logger.Infof("  Interpolation: %v", v.config.Interpolation)
+		v.config.Logger.Infof("  Key Order: %v", v.config.KeyOrder)
 		v.config.Logger.Infof("  Number of Plugins: %d", len(v.plugins))
+		if v.config.Parallelism > 1 {
+			v.config.Logger.Infof("  Parallelism: %d", v.config.Parallelism)
+		}
+		if v.config.PluginTimeout > 0 {
+			v.config.Logger.Infof("  Plugin Timeout: %v", v.config.PluginTimeout)
+		}
+		if len(v.config.Defaults) > 0 {
+			v.config.Logger.Infof("  Defaults: %d", len(v.config.Defaults))
+		}
+		if v.config.UnknownKeys != UnknownKeysAllow {
+			v.config.Logger.Infof("  Unknown Keys: %v", v.config.UnknownKeys)
+		}
+		if v.config.KeyNaming.Style != KeyStyleAny {
+			v.config.Logger.Infof("  Key Style: %v", v.config.KeyNaming.Style)
+		}
 		v.config.Logger.Infof("End of Configuration")
 	}
 
 	v.config.Logger.Infof("Starting validation for file: %s", filePath)
 
-	envVars, err := loadEnvFile(filePath)
+	entries, err := loadEnvFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	violations, err := checkKeyNames(entries, v.config.KeyNaming)
 	if err != nil {
-		return fmt.Errorf("failed to load .env file: %w", err)
+		v.config.Logger.Error(err)
+		return nil, err
+	}
+	if len(violations) > 0 {
+		// Every violation is reported, so that one run shows all badly named keys.
+		errs := make([]error, len(violations))
+		for i, violation := range violations {
+			v.config.Logger.Errorf("Key naming error for key %s: %v", violation.Key, violation)
+			errs[i] = violation
+		}
+		return nil, errors.Join(errs...)
 	}
 
-	for key, value := range envVars {
+	inFile := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		inFile[entry.Key] = true
+	}
+	entries, mapped := v.withDeprecations(entries)
+	entries, defaulted := v.withDefaults(entries)
+	envVars, err := resolveEnvValues(entries, v.config.Interpolation, v.lookupEnv())
+	if err != nil {
+		v.config.Logger.Errorf("Interpolation error: %v", err)
+		return nil, err
+	}
+
+	keys := v.orderedKeys(entries)
+	found := make(map[string]bool, len(keys))
+	results := v.checkKeys(pluginContext(ctx, filePath, envVars), keys, envVars, true)
+
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil && !results[i].checked {
+			return nil, v.canceled(uncheckedKeys(keys[i:], results[i:]), err)
+		}
+
 		if v.config.Verbose {
 			v.config.Logger.Infof("Processing key: %s", key)
 		}
 
+		if !defaulted[key] {
+			found[key] = true
+		}
 		if _, exists := v.requiredKeys[key]; exists {
-			v.requiredKeys[key] = true
 			if v.config.Verbose {
 				v.config.Logger.Infof("  %s is a required variable.", key)
 			}
@@ -124,38 +210,146 @@ func (v *Validator) ValidateDotEnv(filePath string) error {
 				v.config.Logger.Infof("  %s is an optional variable.", key)
 			}
 		}
+		if defaulted[key] && v.config.Verbose {
+			v.config.Logger.Infof("  %s is not set in the file and is defaulted.", key)
+		}
+		if old, ok := mapped[key]; ok && v.config.Verbose {
+			v.config.Logger.Infof("  %s is not set in the file; using the value of deprecated key %s.", key, old)
+		}
+
+		for _, problem := range v.deprecationProblems(key, envVars, inFile) {
+			if plugins.IsWarning(problem) {
+				v.config.Logger.Warnf("Deprecation warning for key %s: %v", key, problem)
+				continue
+			}
+			v.config.Logger.Errorf("Validation error for key %s: %v", key, problem)
+			return nil, problem
+		}
 
-		for _, plugin := range v.plugins {
-			handled, err := plugin.Validate(key, value)
-			if err != nil {
+		for _, outcome := range results[i].outcomes {
+			if plugins.IsWarning(outcome.err) {
+				v.config.Logger.Warnf("Validation warning for key %s: %v", key, outcome.err)
+				continue
+			}
+			if outcome.err != nil {
 				if v.config.Verbose {
-					v.config.Logger.Errorf("Validation error for key %s by %s: %v", key, plugin.Name(), err)
+					v.config.Logger.Errorf("Validation error for key %s by %s: %v", key, outcome.plugin, outcome.err)
 				} else {
-					v.config.Logger.Errorf("Validation error for key %s: %v", key, err)
+					v.config.Logger.Errorf("Validation error for key %s: %v", key, outcome.err)
 				}
-				return err
+				if defaulted[key] {
+					return nil, fmt.Errorf("invalid default value: %w", outcome.err)
+				}
+				return nil, outcome.err
 			}
-			if handled && v.config.Verbose {
-				v.config.Logger.Infof("  [Validated by: %s]", plugin.Name())
+			if outcome.handled && v.config.Verbose {
+				v.config.Logger.Infof("  [Validated by: %s]", outcome.plugin)
+				if outcome.note != "" {
+					v.config.Logger.Infof("  %s", outcome.note)
+				}
 			}
 		}
-	}
 
-	missingKeys := []string{}
-	for key, found := range v.requiredKeys {
-		if !found {
-			missingKeys = append(missingKeys, key)
+		if problem := v.unknownKeyProblem(key, results[i]); problem != nil {
+			if plugins.IsWarning(problem) {
+				v.config.Logger.Warnf("Unknown key warning for key %s: %v", key, problem)
+			} else {
+				v.config.Logger.Errorf("Validation error for key %s: %v", key, problem)
+				return nil, problem
+			}
 		}
 	}
 
+	missingKeys := v.missingKeys(found)
 	if len(missingKeys) > 0 {
 		errMsg := fmt.Sprintf("missing required keys: %v", missingKeys)
 		v.config.Logger.Error(errMsg)
-		return fmt.Errorf(errMsg)
+		return nil, fmt.Errorf(errMsg)
 	}
 
 	v.config.Logger.Infof(".env file is valid.")
-	return nil
+	return &Values{values: envVars, defaulted: defaulted, plugins: v.plugins}, nil
+}
+
+// orderedKeys returns the distinct keys of the parsed entries in the order configured by Config.KeyOrder.
+//
+// Parameters:
+//   - entries: The entries parsed from the `.env` file.
+//
+// Returns:
+//   - []string: Each key once, in file order or sorted order.
+func (v *Validator) orderedKeys(entries []envEntry) []string {
+	keys := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !seen[entry.Key] {
+			seen[entry.Key] = true
+			keys = append(keys, entry.Key)
+		}
+	}
+	if v.config.KeyOrder == KeyOrderSorted {
+		sort.Strings(keys)
+	}
+	return keys
+}
+
+// missingKeys returns the required keys that were not found, in the order they were
+// declared, or sorted if Config.KeyOrder is KeyOrderSorted.
+//
+// Parameters:
+//   - found: The keys present in the `.env` file.
+//
+// Returns:
+//   - []string: The missing required keys.
+func (v *Validator) missingKeys(found map[string]bool) []string {
+	missing := []string{}
+	for _, key := range v.requiredOrder {
+		if !found[key] {
+			missing = append(missing, key)
+		}
+	}
+	if v.config.KeyOrder == KeyOrderSorted {
+		sort.Strings(missing)
+	}
+	return missing
+}
+
+// canceled logs and returns a *CanceledError for the given unchecked keys.
+//
+// Parameters:
+//   - unchecked: The keys that were not checked by every plugin.
+//   - err: The context's error.
+//
+// Returns:
+//   - error: The *CanceledError.
+func (v *Validator) canceled(unchecked []string, err error) error {
+	canceledErr := &CanceledError{Unchecked: append([]string(nil), unchecked...), Err: err}
+	v.config.Logger.Error(canceledErr.Error())
+	return canceledErr
+}
+
+// ensureLogger initializes a default logger if the configuration does not provide one.
+func (v *Validator) ensureLogger() {
+	if v.config.Logger == nil {
+		v.config.Logger = logrus.New()
+		if v.config.Verbose {
+			v.config.Logger.SetLevel(logrus.DebugLevel)
+		} else {
+			v.config.Logger.SetLevel(logrus.InfoLevel)
+		}
+	}
+}
+
+// lookupEnv returns the function used to resolve references to keys the `.env` file does
+// not define: os.LookupEnv, unless Config.FileOnlyReferences is set.
+//
+// Returns:
+//   - func(string) (string, bool): The lookup function, or nil if references must be defined in the file.
+func (v *Validator) lookupEnv() func(string) (string, bool) {
+	if v.config.FileOnlyReferences {
+		return nil
+	}
+	return os.LookupEnv
 }
 
 // loadEnvFile reads and parses the `.env` file from the specified path.
@@ -164,12 +358,12 @@ func (v *Validator) ValidateDotEnv(filePath string) error {
 //   - filePath: The path to the `.env` file.
 //
 // Returns:
-//   - map[string]string: A map containing the key-value pairs from the `.env` file.
+//   - []envEntry: The key-value pairs from the `.env` file, in file order and with references unexpanded.
 //   - error: An error if reading or parsing the file fails.
-func loadEnvFile(filePath string) (map[string]string, error) {
-	envMap, err := godotenv.Read(filePath)
+func loadEnvFile(filePath string) ([]envEntry, error) {
+	entries, err := parseEnvFile(filePath)
 	if err != nil {
 		return nil, err
 	}
-	return envMap, nil
+	return entries, nil
 }
@@ -3,12 +3,17 @@ package validot
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mwiater/go-validot/plugins"
 	"github.com/sirupsen/logrus"
@@ -863,3 +868,290 @@ USE_SSL="yes"
 	assert.NoError(t, err, "Expected no validation errors for keys not handled by any plugin")
 	// Note: CUSTOM_KEY is optional and not handled by any plugin
 }
+
+func TestValidateDotEnv_Interpolation(t *testing.T) {
+	envContent := `
+# Values built from other keys
+
+API_HOST="api.myapp.com"
+API_URL="https://${API_HOST}/v1/"
+DB_HOST=localhost
+DB_PORT=5432
+DB_ADDR=${DB_HOST}:$DB_PORT
+PRICE="\$5"
+LITERAL='${NOT_EXPANDED}'
+`
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		Logger: logger,
+	}, []string{"API_URL", "DB_ADDR"})
+
+	// Validate
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.NoError(t, err, "Expected no validation errors for resolvable references")
+
+	entries, err := loadEnvFile(envFilePath)
+	assert.NoError(t, err)
+	values, err := resolveEnvValues(entries, InterpolateExpanded, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.myapp.com/v1/", values["API_URL"])
+	assert.Equal(t, "localhost:5432", values["DB_ADDR"])
+	assert.Equal(t, "$5", values["PRICE"])
+	assert.Equal(t, "${NOT_EXPANDED}", values["LITERAL"])
+}
+
+func TestValidateDotEnv_InterpolationUndefinedKey(t *testing.T) {
+	envContent := `
+DB_HOST="localhost"
+DB_ADDR="${DB_HOST}:${DB_PORT}"
+`
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		Logger: logger,
+	}, nil)
+
+	// Validate
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err, "Expected validation error for a reference to an undefined key")
+	assert.Contains(t, err.Error(), `value for key "DB_ADDR" references undefined key "DB_PORT"`)
+}
+
+func TestValidateDotEnv_InterpolationEnvironmentFallback(t *testing.T) {
+	t.Setenv("VALIDOT_TEST_PORT", "5432")
+	envFilePath := createTempEnvFile(t, "DB_ADDR=\"localhost:${VALIDOT_TEST_PORT}\"\n")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// By default, keys not defined in the file are looked up in the environment.
+	values, err := NewValidator(Config{Logger: logger}, nil).Load(envFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:5432", values.MustString("DB_ADDR"))
+
+	// With FileOnlyReferences, they are errors.
+	err = NewValidator(Config{Logger: logger, FileOnlyReferences: true}, nil).ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `value for key "DB_ADDR" references undefined key "VALIDOT_TEST_PORT"`)
+}
+
+func TestValidateDotEnv_InterpolationCycle(t *testing.T) {
+	envContent := `
+A="${B}"
+B="prefix-${C}"
+C="$A"
+`
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		Logger: logger,
+	}, nil)
+
+	// Validate
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err, "Expected validation error for an interpolation cycle")
+	assert.Contains(t, err.Error(), "interpolation cycle detected: A -> B -> C -> A")
+}
+
+func TestValidateDotEnv_InterpolationRawMode(t *testing.T) {
+	envContent := `
+API_HOST="api.myapp.com"
+API_URL="https://${API_HOST}/v1/"
+`
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// The expanded value is a valid URL, but the raw value is not.
+	validator := NewValidator(Config{
+		Logger:        logger,
+		Interpolation: InterpolateRaw,
+	}, nil)
+
+	// Validate
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err, "Expected validation error when validating the raw value")
+	assert.Contains(t, err.Error(), "value for key \"API_URL\" must be a valid URL")
+}
+
+func TestValidateDotEnv_DeterministicKeyOrder(t *testing.T) {
+	envContent := `
+ZEBRA="1"
+ENVIRONMENT="STAGING"
+ALPHA="2"
+MIDDLE="3"
+`
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	run := func(order KeyOrder) string {
+		var logBuf bytes.Buffer
+		logger := logrus.New()
+		logger.SetOutput(&logBuf)
+		logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+		validator := NewValidator(Config{
+			Verbose:  true,
+			Logger:   logger,
+			KeyOrder: order,
+		}, []string{"REQUIRED_Z", "ALPHA", "REQUIRED_A"})
+
+		err := validator.ValidateDotEnv(envFilePath)
+		assert.Error(t, err)
+		return logBuf.String()
+	}
+
+	processed := func(logs string) []string {
+		var keys []string
+		for _, line := range strings.Split(logs, "\n") {
+			if i := strings.Index(line, "Processing key: "); i >= 0 {
+				keys = append(keys, strings.TrimSuffix(line[i+len("Processing key: "):], `"`))
+			}
+		}
+		return keys
+	}
+
+	// File order is the default, and output is identical between runs.
+	logs := run(KeyOrderFile)
+	assert.Equal(t, logs, run(KeyOrderFile))
+	assert.Equal(t, []string{"ZEBRA", "ENVIRONMENT", "ALPHA", "MIDDLE"}, processed(logs))
+	assert.Contains(t, logs, "missing required keys: [REQUIRED_Z REQUIRED_A]")
+
+	logs = run(KeyOrderSorted)
+	assert.Equal(t, logs, run(KeyOrderSorted))
+	assert.Equal(t, []string{"ALPHA", "ENVIRONMENT", "MIDDLE", "ZEBRA"}, processed(logs))
+	assert.Contains(t, logs, "missing required keys: [REQUIRED_A REQUIRED_Z]")
+}
+
+func TestValidateDotEnv_ReusedValidatorReportsMissingKeys(t *testing.T) {
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		Logger: logger,
+	}, []string{"DB_HOST"})
+
+	// A key found in one file must not count as found when validating another.
+	assert.NoError(t, validator.ValidateDotEnv(createTempEnvFile(t, `DB_HOST="localhost"`)))
+	err := validator.ValidateDotEnv(createTempEnvFile(t, `DB_PORT="5432"`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required keys: [DB_HOST]")
+}
+
+func TestValidateDotEnv_PathsResolveRelativeToEnvFile(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "SSL_CERT_PATH=certs/server.pem\n")
+	certDir := filepath.Join(filepath.Dir(envFilePath), "certs")
+	assert.NoError(t, os.Mkdir(certDir, 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(certDir, "server.pem"), []byte("-----BEGIN CERTIFICATE-----\n"), 0600))
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator := NewValidator(Config{
+		Logger:  logger,
+		Plugins: []plugins.ValidationPlugin{&plugins.PathValidationPlugin{Key: "SSL_CERT_PATH", MustExist: true, Type: "file"}},
+	}, nil)
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+
+	assert.NoError(t, os.Remove(filepath.Join(certDir, "server.pem")))
+	err := validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("path %q for key \"SSL_CERT_PATH\" does not exist", filepath.Join(certDir, "server.pem")))
+}
+
+func TestValidateDotEnv_TLSCertificateMatchesKey(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, certPEM := createTestCertificate(t, "service.internal", time.Now().Add(10*24*time.Hour), false, key, nil, nil)
+
+	envFilePath := createTempEnvFile(t, "TLS_CERT_PATH=server.pem\nTLS_KEY_PATH=server.key\n")
+	dir := filepath.Dir(envFilePath)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "server.pem"), []byte(certPEM), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "server.key"), []byte(encodeTestKey(t, key)), 0600))
+
+	var logBuffer bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logBuffer)
+
+	validator := NewValidator(Config{
+		Logger: logger,
+		Plugins: []plugins.ValidationPlugin{
+			&plugins.TLSValidationPlugin{Key: "TLS_CERT_PATH", MatchingKey: "TLS_KEY_PATH", ExpiryWarningDays: 30},
+			&plugins.TLSValidationPlugin{Key: "TLS_KEY_PATH", MatchingKey: "TLS_CERT_PATH"},
+		},
+	}, nil)
+
+	// An expiring certificate is a warning, not a failure.
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+	assert.Contains(t, logBuffer.String(), "Validation warning for key TLS_CERT_PATH: certificate for key \\\"TLS_CERT_PATH\\\" expires in 9 days")
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	if assert.Len(t, findings, 1) {
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+		assert.Equal(t, envFilePath+":1: warning: "+findings[0].Message, findings[0].String())
+	}
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "server.key"), []byte(encodeTestKey(t, otherKey)), 0600))
+	err = validator.ValidateDotEnv(envFilePath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `certificate for key "TLS_CERT_PATH" does not match the private key in "TLS_KEY_PATH"`)
+
+	findings, err = validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	var messages []string
+	for _, finding := range findings {
+		messages = append(messages, finding.Severity.String()+": "+finding.Message)
+	}
+	assert.Contains(t, messages, `error: private key for key "TLS_KEY_PATH" does not match the certificate in "TLS_CERT_PATH"`)
+}
+
+func TestValidateDotEnv_VerboseCronPreview(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "BACKUP_CRON=\"30 2 * * *\"\n")
+
+	var logBuffer bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logBuffer)
+
+	plugin := &plugins.CronValidationPlugin{Key: "BACKUP_CRON"}
+	validator := NewValidator(Config{
+		Verbose: true,
+		Logger:  logger,
+		Plugins: []plugins.ValidationPlugin{plugin},
+	}, nil)
+
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+	assert.Contains(t, logBuffer.String(), "[Validated by: CronValidationPlugin]")
+	assert.Contains(t, logBuffer.String(), "Next run: ")
+	assert.Contains(t, logBuffer.String(), "T02:30:00Z")
+
+	// The preview is only computed in verbose mode.
+	logBuffer.Reset()
+	validator = NewValidator(Config{Logger: logger, Plugins: []plugins.ValidationPlugin{plugin}}, nil)
+	assert.NoError(t, validator.ValidateDotEnv(envFilePath))
+	assert.NotContains(t, logBuffer.String(), "Next run")
+}
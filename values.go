@@ -0,0 +1,305 @@
+package validot
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mwiater/go-validot/plugins"
+)
+
+// Values holds the values of a validated `.env` file and reads them back as typed values.
+// Accessors interpret a value the way the plugin that validated its key does, so that, for
+// example, `ENABLE_DEBUG=yes` accepted by a BooleanValidationPlugin is read back as true.
+type Values struct {
+	values    map[string]string          // The values of the `.env` file, after interpolation, including defaults.
+	defaulted map[string]bool            // The keys whose values come from Config.Defaults.
+	plugins   []plugins.ValidationPlugin // The Validator's plugins, consulted to interpret values.
+}
+
+// Load validates the `.env` file at the specified path like ValidateDotEnv and, if it is
+// valid, returns its values for typed access. Keys the file does not set have their
+// values from Config.Defaults, if any.
+//
+// Parameters:
+//   - filePath: The path to the `.env` file to validate.
+//
+// Returns:
+//   - *Values: The values of the `.env` file.
+//   - error: An error if validation fails, as returned by ValidateDotEnv.
+func (v *Validator) Load(filePath string) (*Values, error) {
+	return v.LoadContext(context.Background(), filePath)
+}
+
+// LoadContext is like Load, but stops when the context is canceled or its deadline passes.
+//
+// Parameters:
+//   - ctx: The context for the validation.
+//   - filePath: The path to the `.env` file to validate.
+//
+// Returns:
+//   - *Values: The values of the `.env` file.
+//   - error: An error if validation fails, as returned by ValidateDotEnvContext.
+func (v *Validator) LoadContext(ctx context.Context, filePath string) (*Values, error) {
+	return v.validate(ctx, filePath)
+}
+
+// Lookup returns the raw value of a key.
+//
+// Parameters:
+//   - key: The key to look up.
+//
+// Returns:
+//   - string: The value, after interpolation.
+//   - bool: False if the key is not set.
+func (vals *Values) Lookup(key string) (string, bool) {
+	value, ok := vals.values[key]
+	return value, ok
+}
+
+// Defaulted reports whether the value of a key comes from Config.Defaults rather than the file.
+//
+// Parameters:
+//   - key: The key to check.
+//
+// Returns:
+//   - bool: True if the key is not set in the file and has a default.
+func (vals *Values) Defaulted(key string) bool {
+	return vals.defaulted[key]
+}
+
+// String returns the value of a key. If an EnumValidationPlugin validates the key, the
+// canonical allowed value is returned, with aliases and letter case resolved.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - string: The value.
+//   - error: An error if the key is not set.
+func (vals *Values) String(key string) (string, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return "", err
+	}
+	if enum, ok := pluginFor(vals.plugins, key, func(p *plugins.EnumValidationPlugin) string { return p.Key }); ok {
+		return enum.Canonical(value)
+	}
+	return value, nil
+}
+
+// Int returns the value of a key as a base-10 integer. Surrounding whitespace is ignored,
+// like it is by the plugins that interpret values, such as plugins.ParseBool.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - int: The value.
+//   - error: An error if the key is not set or is not an integer.
+func (vals *Values) Int(key string) (int, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("value for key %q must be an integer, got %q", key, value)
+	}
+	return n, nil
+}
+
+// Bool returns the value of a key as a boolean. If a BooleanValidationPlugin validates the
+// key, the value must be one of its accepted values; values are interpreted with plugins.ParseBool.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - bool: The value.
+//   - error: An error if the key is not set or is not a boolean.
+func (vals *Values) Bool(key string) (bool, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return false, err
+	}
+	if boolean, ok := pluginFor(vals.plugins, key, func(p *plugins.BooleanValidationPlugin) string { return p.Key }); ok {
+		return boolean.Bool(value)
+	}
+	b, err := plugins.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("value for key %q must be a boolean, got %q", key, value)
+	}
+	return b, nil
+}
+
+// Duration returns the value of a key as a duration, such as "30s" or "1h15m". Surrounding
+// whitespace is ignored.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - time.Duration: The value.
+//   - error: An error if the key is not set or is not a duration.
+func (vals *Values) Duration(key string) (time.Duration, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return 0, err
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("value for key %q must be a duration, got %q", key, value)
+	}
+	return d, nil
+}
+
+// URL returns the value of a key as an absolute URL, parsed with plugins.ParseURL like
+// URLValidationPlugin does.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - *url.URL: The value.
+//   - error: An error if the key is not set or is not a URL with a scheme and a host.
+func (vals *Values) URL(key string) (*url.URL, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return nil, err
+	}
+	u, err := plugins.ParseURL(value)
+	if err != nil {
+		return nil, fmt.Errorf("value for key %q must be a URL: %v", key, err)
+	}
+	return u, nil
+}
+
+// IP returns the value of a key as an IP address, parsed with plugins.ParseIP like
+// IPAddressValidationPlugin does: surrounding whitespace is ignored and IPv4-mapped IPv6
+// addresses are unmapped.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - netip.Addr: The value.
+//   - error: An error if the key is not set or is not a single IP address.
+func (vals *Values) IP(key string) (netip.Addr, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addr, err := plugins.ParseIP(value)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("value for key %q must be an IP address, got %q", key, value)
+	}
+	return addr, nil
+}
+
+// List returns the elements of a list value. If a ListValidationPlugin validates the key,
+// its separator and trimming are used; otherwise elements are separated by commas and
+// trimmed. An empty value has no elements.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - []string: The elements.
+//   - error: An error if the key is not set.
+func (vals *Values) List(key string) ([]string, error) {
+	value, err := vals.get(key)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := pluginFor(vals.plugins, key, func(p *plugins.ListValidationPlugin) string { return p.Key })
+	if !ok {
+		list = &plugins.ListValidationPlugin{Key: key, TrimSpace: true}
+	}
+	return list.Elements(value), nil
+}
+
+// MustString is like String but panics if the value cannot be read.
+func (vals *Values) MustString(key string) string {
+	return must(vals.String(key))
+}
+
+// MustInt is like Int but panics if the value cannot be read.
+func (vals *Values) MustInt(key string) int {
+	return must(vals.Int(key))
+}
+
+// MustBool is like Bool but panics if the value cannot be read.
+func (vals *Values) MustBool(key string) bool {
+	return must(vals.Bool(key))
+}
+
+// MustDuration is like Duration but panics if the value cannot be read.
+func (vals *Values) MustDuration(key string) time.Duration {
+	return must(vals.Duration(key))
+}
+
+// MustURL is like URL but panics if the value cannot be read.
+func (vals *Values) MustURL(key string) *url.URL {
+	return must(vals.URL(key))
+}
+
+// MustIP is like IP but panics if the value cannot be read.
+func (vals *Values) MustIP(key string) netip.Addr {
+	return must(vals.IP(key))
+}
+
+// MustList is like List but panics if the value cannot be read.
+func (vals *Values) MustList(key string) []string {
+	return must(vals.List(key))
+}
+
+// get returns the raw value of a key.
+//
+// Parameters:
+//   - key: The key to read.
+//
+// Returns:
+//   - string: The value.
+//   - error: An error if the key is not set.
+func (vals *Values) get(key string) (string, error) {
+	value, ok := vals.values[key]
+	if !ok {
+		return "", fmt.Errorf("key %q is not set", key)
+	}
+	return value, nil
+}
+
+// pluginFor returns the last plugin of type T configured for the key. User plugins follow
+// the built-in plugins, so a user plugin takes precedence.
+//
+// Parameters:
+//   - list: The plugins to search.
+//   - key: The key the plugin must be configured for.
+//   - keyOf: Returns the key a plugin is configured for.
+//
+// Returns:
+//   - T: The plugin.
+//   - bool: False if no plugin of type T is configured for the key.
+func pluginFor[T plugins.ValidationPlugin](list []plugins.ValidationPlugin, key string, keyOf func(T) string) (T, bool) {
+	var found T
+	ok := false
+	for _, plugin := range list {
+		if p, isT := plugin.(T); isT && keyOf(p) == key {
+			found, ok = p, true
+		}
+	}
+	return found, ok
+}
+
+// must returns value, panicking if err is not nil.
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,125 @@
+// values_test.go
+package validot
+
+import (
+	"io"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/mwiater/go-validot/plugins"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_TypedAccessors(t *testing.T) {
+	envContent := "API_URL=https://api.myapp.com/v1/\n" +
+		"ENVIRONMENT=PRODUCTION\n" +
+		"ENABLE_DEBUG=yes\n" +
+		"TRUSTED_PROXY_IP=10.0.0.1\n" +
+		"REGION=eu\n" +
+		"FEATURE_FLAG=on\n" +
+		"WORKERS=8\n" +
+		"SERVICE_TIMEOUT=1m30s\n" +
+		"KAFKA_BROKERS=h1:9092; h2:9092\n" +
+		"CORS_ORIGINS=https://a.example.com, https://b.example.com\n" +
+		"EMPTY=\n"
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator
+	validator := NewValidator(Config{
+		Logger: logger,
+		Plugins: []plugins.ValidationPlugin{
+			&plugins.EnumValidationPlugin{Key: "REGION", AllowedValues: []string{"EU-WEST-1", "US-EAST-1"}, Aliases: map[string]string{"eu": "EU-WEST-1"}},
+			&plugins.ListValidationPlugin{Key: "KAFKA_BROKERS", Separator: ";", TrimSpace: true},
+		},
+	}, nil)
+
+	values, err := validator.Load(envFilePath)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "PRODUCTION", values.MustString("ENVIRONMENT"))
+	assert.Equal(t, "EU-WEST-1", values.MustString("REGION"), "Enum aliases resolve to the allowed value")
+	raw, ok := values.Lookup("REGION")
+	assert.True(t, ok)
+	assert.Equal(t, "eu", raw)
+
+	assert.True(t, values.MustBool("ENABLE_DEBUG"), "Validated by the built-in BooleanValidationPlugin")
+	assert.True(t, values.MustBool("FEATURE_FLAG"))
+	assert.Equal(t, 8, values.MustInt("WORKERS"))
+	assert.Equal(t, 90*time.Second, values.MustDuration("SERVICE_TIMEOUT"))
+	assert.Equal(t, "api.myapp.com", values.MustURL("API_URL").Host)
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), values.MustIP("TRUSTED_PROXY_IP"))
+	assert.Equal(t, []string{"h1:9092", "h2:9092"}, values.MustList("KAFKA_BROKERS"))
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, values.MustList("CORS_ORIGINS"))
+	assert.Empty(t, values.MustList("EMPTY"))
+
+	_, err = values.String("MISSING")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `key "MISSING" is not set`)
+
+	_, err = values.Int("SERVICE_TIMEOUT")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `value for key "SERVICE_TIMEOUT" must be an integer, got "1m30s"`)
+
+	_, err = values.Bool("REGION")
+	assert.Error(t, err)
+	_, err = values.Duration("WORKERS")
+	assert.Error(t, err)
+	_, err = values.IP("API_URL")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { values.MustInt("MISSING") })
+}
+
+func TestLoad_AccessorsAgreeWithPlugins(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "TRUSTED_PROXY_IP=\" ::ffff:10.0.0.1 \"\nWORKERS=\" 8 \"\nCALLBACK_URL=/relative/path\n")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	values, err := NewValidator(Config{Logger: logger}, nil).Load(envFilePath)
+	assert.NoError(t, err)
+
+	// The built-in IPAddressValidationPlugin trims and unmaps the address, and so does IP.
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), values.MustIP("TRUSTED_PROXY_IP"))
+	assert.Equal(t, 8, values.MustInt("WORKERS"))
+
+	// URLValidationPlugin requires a scheme and a host, and so does URL.
+	_, err = values.URL("CALLBACK_URL")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `value for key "CALLBACK_URL" must be a URL`)
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "ENABLE_DEBUG=maybe\n")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator := NewValidator(Config{Logger: logger}, nil)
+	values, err := validator.Load(envFilePath)
+	assert.Error(t, err)
+	assert.Nil(t, values)
+}
+
+func TestLoad_BooleanPluginAcceptedValues(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "USE_SSL=on\n")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Values are read with the plugin that validated the key, so its accepted values apply.
+	plugin := &plugins.BooleanValidationPlugin{Key: "USE_SSL", AcceptedValues: []string{"on", "off"}}
+	values, err := NewValidator(Config{Logger: logger, Plugins: []plugins.ValidationPlugin{plugin}}, nil).Load(envFilePath)
+	assert.NoError(t, err)
+	assert.True(t, values.MustBool("USE_SSL"))
+
+	plugin.AcceptedValues = []string{"true", "false"}
+	_, err = values.Bool("USE_SSL")
+	assert.Error(t, err)
+}
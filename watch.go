@@ -0,0 +1,144 @@
+package validot
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+)
+
+const (
+	// DefaultWatchInterval is how often watched files are polled when Config.WatchInterval is not set.
+	DefaultWatchInterval = 500 * time.Millisecond
+	// DefaultWatchDebounce is how long a file must be unchanged before it is re-validated when Config.WatchDebounce is not set.
+	DefaultWatchDebounce = 250 * time.Millisecond
+)
+
+// WatchEvent reports how the findings for a watched `.env` file changed after it was edited.
+// The first event for each file lists all of its current findings as introduced.
+type WatchEvent struct {
+	File       string    // The path to the `.env` file that was re-validated.
+	Introduced []Finding // Findings that were not present in the previous validation.
+	Fixed      []Finding // Findings from the previous validation that are no longer present.
+	Err        error     // An error if the file could not be read or parsed; findings are then left unchanged.
+}
+
+// watchedFile tracks the polling state of a single watched file.
+type watchedFile struct {
+	path       string    // The path to the file.
+	contents   []byte    // The contents seen at the last poll.
+	readErr    bool      // Whether the last poll failed to read the file.
+	changedAt  time.Time // When a change was last observed.
+	pending    bool      // Whether a change has been observed but not yet validated.
+	findings   []Finding // The findings from the last successful validation.
+	lastErrMsg string    // The error from the last validation, used to avoid repeating events.
+}
+
+// Watch validates the given `.env` files and re-validates each one whenever it changes,
+// until the context is canceled. Files are polled, so no OS-specific file notification
+// support is needed. Changes are debounced, and the callback only receives the findings
+// that were introduced or fixed since the previous validation of that file.
+//
+// Parameters:
+//   - ctx: A context that stops watching when canceled.
+//   - paths: The paths to the `.env` files to watch.
+//   - callback: A function called with each change in findings; it is called from the watching goroutine.
+//
+// Returns:
+//   - error: The context's error once watching stops.
+func (v *Validator) Watch(ctx context.Context, paths []string, callback func(WatchEvent)) error {
+	v.ensureLogger()
+
+	interval := v.config.WatchInterval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	debounce := v.config.WatchDebounce
+	if debounce < 0 {
+		debounce = 0
+	} else if debounce == 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	files := make([]*watchedFile, len(paths))
+	for i, path := range paths {
+		files[i] = &watchedFile{path: path}
+		files[i].contents, _ = os.ReadFile(path)
+		v.revalidate(ctx, files[i], callback)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			for _, file := range files {
+				contents, err := os.ReadFile(file.path)
+				if (err != nil) != file.readErr || !bytes.Equal(contents, file.contents) {
+					file.contents = contents
+					file.readErr = err != nil
+					file.changedAt = now
+					file.pending = true
+				}
+
+				if file.pending && now.Sub(file.changedAt) >= debounce {
+					file.pending = false
+					if v.config.Verbose {
+						v.config.Logger.Infof("Change detected, re-validating file: %s", file.path)
+					}
+					v.revalidate(ctx, file, callback)
+				}
+			}
+		}
+	}
+}
+
+// revalidate validates a watched file and reports the findings that changed.
+//
+// Parameters:
+//   - ctx: The context for the validation; nothing is reported once it is done.
+//   - file: The watched file to validate.
+//   - callback: The function to report changes to.
+func (v *Validator) revalidate(ctx context.Context, file *watchedFile, callback func(WatchEvent)) {
+	findings, err := v.FindingsContext(ctx, file.path)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		if err.Error() != file.lastErrMsg {
+			file.lastErrMsg = err.Error()
+			callback(WatchEvent{File: file.path, Err: err})
+		}
+		return
+	}
+	file.lastErrMsg = ""
+
+	previous := make(map[string]bool, len(file.findings))
+	for _, finding := range file.findings {
+		previous[finding.identity()] = true
+	}
+	current := make(map[string]bool, len(findings))
+	for _, finding := range findings {
+		current[finding.identity()] = true
+	}
+
+	event := WatchEvent{File: file.path}
+	for _, finding := range findings {
+		if !previous[finding.identity()] {
+			event.Introduced = append(event.Introduced, finding)
+		}
+	}
+	for _, finding := range file.findings {
+		if !current[finding.identity()] {
+			event.Fixed = append(event.Fixed, finding)
+		}
+	}
+	file.findings = findings
+
+	if len(event.Introduced) > 0 || len(event.Fixed) > 0 {
+		callback(event)
+	}
+}
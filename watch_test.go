@@ -0,0 +1,95 @@
+// watch_test.go
+package validot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindings_ReportsAllProblems(t *testing.T) {
+	envContent := `
+API_URL="ftp://api.myapp.com"
+ENVIRONMENT="TESTING"
+ENABLE_DEBUG="true"
+`
+
+	envFilePath := createTempEnvFile(t, envContent)
+
+	// Create validator
+	validator := NewValidator(Config{}, []string{"API_URL", "DB_HOST"})
+
+	findings, err := validator.Findings(envFilePath)
+	assert.NoError(t, err)
+	assert.Len(t, findings, 3)
+
+	assert.Equal(t, "API_URL", findings[0].Key)
+	assert.Equal(t, 2, findings[0].Line)
+	assert.Equal(t, "URLValidationPlugin", findings[0].Plugin)
+
+	assert.Equal(t, "ENVIRONMENT", findings[1].Key)
+	assert.Equal(t, 3, findings[1].Line)
+
+	assert.Equal(t, "DB_HOST", findings[2].Key)
+	assert.Equal(t, envFilePath+": missing required key: DB_HOST", findings[2].String())
+}
+
+func TestWatch_ReportsIntroducedAndFixedFindings(t *testing.T) {
+	envFilePath := createTempEnvFile(t, "ENVIRONMENT=\"STAGING\"\n")
+
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Create validator that polls quickly
+	validator := NewValidator(Config{
+		Logger:        logger,
+		WatchInterval: 5 * time.Millisecond,
+		WatchDebounce: 20 * time.Millisecond,
+	}, nil)
+
+	events := make(chan WatchEvent, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- validator.Watch(ctx, []string{envFilePath}, func(event WatchEvent) {
+			events <- event
+		})
+	}()
+
+	nextEvent := func() WatchEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for watch event")
+			return WatchEvent{}
+		}
+	}
+
+	// Introduce an invalid value.
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(t, os.WriteFile(envFilePath, []byte("ENVIRONMENT=\"TESTING\"\n"), 0644))
+	event := nextEvent()
+	assert.Len(t, event.Introduced, 1)
+	assert.Empty(t, event.Fixed)
+	assert.Equal(t, "ENVIRONMENT", event.Introduced[0].Key)
+
+	// Moving the key to another line does not re-report the same problem, but fixing it does.
+	assert.NoError(t, os.WriteFile(envFilePath, []byte("\n\nENVIRONMENT=\"TESTING\"\n"), 0644))
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, os.WriteFile(envFilePath, []byte("ENVIRONMENT=\"PRODUCTION\"\n"), 0644))
+	event = nextEvent()
+	assert.Empty(t, event.Introduced)
+	assert.Len(t, event.Fixed, 1)
+
+	cancel()
+	assert.True(t, errors.Is(<-done, context.Canceled))
+	assert.Empty(t, events)
+}